@@ -4,6 +4,9 @@ import "github.com/spf13/cobra"
 
 func init() {
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(lspCmd)
+	rootCmd.AddCommand(dumpCmd)
 }
 
 const cliDescription = `
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bak-minsu/seclang-linter/pkg/dump"
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/spf13/cobra"
+)
+
+const dumpDescription = `
+Print the parse tree of a file, with the offset, line and column
+of every node, for attaching to bug reports and building tooling.
+The tree holds directives and their options, along with the
+variables, operator and actions of rules.
+`
+
+// formats the dump command can print
+const (
+	dumpFormatText = "text"
+	dumpFormatJSON = "json"
+)
+
+// options of the dump command
+var dumpOptions struct {
+	// format the tree is printed in
+	format string
+}
+
+func init() {
+	dumpCmd.Flags().StringVar(
+		&dumpOptions.format,
+		"format",
+		dumpFormatText,
+		"format of the printed tree, one of: text, json",
+	)
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump [OPTIONS] <path to seclang file>",
+	Short: "Prints the parse tree of a file",
+	Long:  dumpDescription,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		file, err := parse.ParseFile(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		root := dump.Build(file)
+
+		switch dumpOptions.format {
+		case dumpFormatText:
+			err = dump.Text(os.Stdout, root)
+		case dumpFormatJSON:
+			err = dump.JSON(os.Stdout, root)
+		default:
+			err = fmt.Errorf("unknown format %q, expected one of: text, json", dumpOptions.format)
+		}
+
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
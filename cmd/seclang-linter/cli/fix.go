@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bak-minsu/seclang-linter/pkg/format"
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// represents the fixes applied to a single file
+type fixedFile struct {
+	// name of the file
+	name string
+
+	// contents of the file before fixing
+	before []byte
+
+	// contents of the file after fixing
+	contents []byte
+
+	// fixes applied to the file
+	applied []*parse.SuggestedFix
+}
+
+// applies the suggested fixes of the findings to the files they
+// were found in and returns the files which changed, in load order
+func fixFiles(files []*parse.File, findings error) []*fixedFile {
+	fixes := suggestedFixes(findings)
+
+	var fixed []*fixedFile
+
+	for _, file := range files {
+		if len(fixes[file.Name()]) == 0 {
+			continue
+		}
+
+		fixed = append(fixed, fixFile(file.Name(), file.Contents(), fixes[file.Name()]))
+	}
+
+	return fixed
+}
+
+// applies the suggested fixes of errors which stopped files from
+// parsing, such as whitespace after a line continuation, and
+// returns the files which changed, in the order of the errors
+func fixParseErrors(err error) []*fixedFile {
+	var (
+		fixes = suggestedFixes(err)
+		fixed []*fixedFile
+		seen  = map[string]bool{}
+	)
+
+	for _, linterError := range parse.LinterErrors(err) {
+		if len(fixes[linterError.File]) == 0 || seen[linterError.File] {
+			continue
+		}
+
+		seen[linterError.File] = true
+
+		fixed = append(fixed, fixFile(linterError.File, []byte(linterError.Contents), fixes[linterError.File]))
+	}
+
+	return fixed
+}
+
+// returns the suggested fixes of the linter errors keyed by file name
+func suggestedFixes(err error) map[string][]*parse.SuggestedFix {
+	fixes := map[string][]*parse.SuggestedFix{}
+
+	for _, linterError := range parse.LinterErrors(err) {
+		if linterError.Fix != nil {
+			fixes[linterError.File] = append(fixes[linterError.File], linterError.Fix)
+		}
+	}
+
+	return fixes
+}
+
+// applies the fixes to the contents of the named file
+func fixFile(name string, before []byte, fixes []*parse.SuggestedFix) *fixedFile {
+	contents, applied := parse.ApplyFixes(before, fixes)
+
+	return &fixedFile{name: name, before: before, contents: contents, applied: applied}
+}
+
+// prints the changes the fixes make to every file
+func printFixDiffs(fixed []*fixedFile) {
+	for _, f := range fixed {
+		fmt.Print(format.Diff(f.name, f.before, f.contents))
+	}
+}
+
+// writes the fixed contents of every file, replacing each file
+// at once so an interrupted write never leaves it half fixed
+func writeFixedFiles(fixed []*fixedFile) error {
+	for _, f := range fixed {
+		if err := writeFileAtomic(f.name, f.contents); err != nil {
+			return err
+		}
+
+		fmt.Printf("applied %d fixes to %s\n", len(f.applied), f.name)
+	}
+
+	return nil
+}
+
+// writes the contents to a temporary file next to the named
+// file, then renames the temporary file over it
+func writeFileAtomic(name string, contents []byte) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return fmt.Errorf("could not read file %q: %w", name, err)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return fmt.Errorf("could not write file %q: %w", name, err)
+	}
+
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(contents); err != nil {
+		temp.Close()
+
+		return fmt.Errorf("could not write file %q: %w", name, err)
+	}
+
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("could not write file %q: %w", name, err)
+	}
+
+	if err := os.Chmod(temp.Name(), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("could not write file %q: %w", name, err)
+	}
+
+	if err := os.Rename(temp.Name(), name); err != nil {
+		return fmt.Errorf("could not write file %q: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bak-minsu/seclang-linter/pkg/format"
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/spf13/cobra"
+)
+
+const fmtDescription = `
+Format files within given paths in the house style of
+the OWASP Core Ruleset, rewriting them in place.
+Given paths can be any of the following:
+- Path to a file
+- Glob path, ex. "./some/path/*"
+`
+
+// options of the fmt command
+var fmtOptions struct {
+	// lists unformatted files and fails instead of rewriting them
+	check bool
+
+	// prints the changes instead of rewriting the files
+	diff bool
+}
+
+func init() {
+	fmtCmd.Flags().BoolVar(
+		&fmtOptions.check,
+		"check",
+		false,
+		"list files which are not formatted and exit with status 1 instead of rewriting them",
+	)
+	fmtCmd.Flags().BoolVar(
+		&fmtOptions.diff,
+		"diff",
+		false,
+		"print the changes formatting would make instead of rewriting the files",
+	)
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [OPTIONS] <path to seclang file> <additional paths to seclang files>...",
+	Short: "Formats given paths",
+	Long:  fmtDescription,
+	Run: func(cmd *cobra.Command, args []string) {
+		unformatted := false
+
+		for _, pattern := range args {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				fmt.Println(fmt.Errorf("invalid glob pattern: %w", err))
+				os.Exit(1)
+			}
+
+			for _, match := range matches {
+				changed, err := formatFile(match)
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+
+				unformatted = unformatted || changed
+			}
+		}
+
+		if fmtOptions.check && unformatted {
+			os.Exit(1)
+		}
+	},
+}
+
+// formats the file with the given name according to the
+// fmt options, reporting whether formatting changed it
+func formatFile(name string) (bool, error) {
+	file, err := parse.ParseFile(name)
+	if err != nil {
+		return false, err
+	}
+
+	formatted, err := format.Format(file)
+	if err != nil {
+		return false, err
+	}
+
+	if bytes.Equal(formatted, file.Contents()) {
+		return false, nil
+	}
+
+	switch {
+	case fmtOptions.diff:
+		fmt.Print(format.Diff(name, file.Contents(), formatted))
+	case fmtOptions.check:
+		fmt.Println(name)
+	default:
+		info, err := os.Stat(name)
+		if err != nil {
+			return false, fmt.Errorf("could not read file %q: %w", name, err)
+		}
+
+		if err := os.WriteFile(name, formatted, info.Mode().Perm()); err != nil {
+			return false, fmt.Errorf("could not write file %q: %w", name, err)
+		}
+
+		fmt.Println(name)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bak-minsu/seclang-linter/pkg/lsp"
+	"github.com/spf13/cobra"
+)
+
+const lspDescription = `
+Start a language server speaking the Language Server Protocol
+over stdin and stdout. The server publishes diagnostics of opened
+documents, and offers hover documentation, completion of directive
+names, and go to definition for skipAfter markers and rule IDs.
+`
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Starts a language server over stdio",
+	Long:  lspDescription,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := lsp.NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/bak-minsu/seclang-linter/pkg/report"
+)
+
+// prints the error returned while parsing files, the errors
+// found within the files in the given format and the others,
+// such as files which could not be read, as they are
+func printParseErrors(err error, format string, options parse.RenderOptions) {
+	for _, other := range otherErrors(err) {
+		fmt.Println(other)
+	}
+
+	if linterErrors := parse.LinterErrors(err); len(linterErrors) > 0 {
+		printFindings(linterErrors, format, options)
+	}
+}
+
+// returns the errors joined into the given error which hold no linter errors
+func otherErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var others []error
+		for _, wrapped := range joined.Unwrap() {
+			others = append(others, otherErrors(wrapped)...)
+		}
+
+		return others
+	}
+
+	if err == nil || len(parse.LinterErrors(err)) > 0 {
+		return nil
+	}
+
+	return []error{err}
+}
+
+// prints the findings in the given format
+func printFindings(findings []*parse.LinterError, format string, options parse.RenderOptions) {
+	if err := report.Write(os.Stdout, format, findings, options); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// returns the findings as severe as the threshold or more,
+// nil if there are none
+func reportedFindings(findings error, threshold int) error {
+	var reported []error
+
+	for _, finding := range parse.LinterErrors(findings) {
+		if parse.LevelAtLeast(finding.ParseLevel, threshold) {
+			reported = append(reported, finding)
+		}
+	}
+
+	return errors.Join(reported...)
+}
+
+// reports whether the findings hold no errors and at most the
+// maximum number of warnings, printing why the run fails otherwise
+func passes(findings error, maxWarnings int) bool {
+	errorCount, warningCount := 0, 0
+
+	for _, finding := range parse.LinterErrors(findings) {
+		switch finding.ParseLevel {
+		case parse.ParseLevelError:
+			errorCount++
+		case parse.ParseLevelWarning:
+			warningCount++
+		}
+	}
+
+	if maxWarnings >= 0 && warningCount > maxWarnings {
+		fmt.Printf("found %d warnings, more than the maximum of %d\n", warningCount, maxWarnings)
+
+		return false
+	}
+
+	return errorCount == 0
+}
@@ -2,8 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
 
+	"github.com/bak-minsu/seclang-linter/pkg/analyze"
 	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/bak-minsu/seclang-linter/pkg/report"
 	"github.com/spf13/cobra"
 )
 
@@ -15,13 +21,252 @@ Given paths can be any of the following:
 - Glob path, ex. "./some/path/*"
 `
 
+// options of the run command
+var runOptions struct {
+	// options the files are analyzed with
+	analyze analyze.Options
+
+	// prints only the findings
+	quiet bool
+
+	// prints details of the parsed and analyzed files
+	verbose bool
+
+	// applies the suggested fixes of the findings
+	fix bool
+
+	// prints the changes the suggested fixes would make
+	fixDryRun bool
+
+	// name of the least severe level of reported findings
+	severityThreshold string
+
+	// number of warnings above which the run fails,
+	// negative to never fail because of warnings
+	maxWarnings int
+
+	// columns lines of findings are wrapped at, zero
+	// to detect the width of the terminal
+	width int
+
+	// number of lines shown above and below each finding
+	context int
+
+	// format the findings are printed in
+	format string
+}
+
+func init() {
+	runCmd.Flags().StringVar(
+		&runOptions.analyze.DataRoot,
+		"data-root",
+		"",
+		"directory to resolve operator data files against, defaults to the directory of each rule file",
+	)
+	runCmd.Flags().IntVar(
+		&runOptions.analyze.Jobs,
+		"jobs",
+		runtime.GOMAXPROCS(0),
+		"number of files parsed and analyzed at once",
+	)
+	runCmd.Flags().BoolVarP(
+		&runOptions.quiet,
+		"quiet",
+		"q",
+		false,
+		"print only the findings, without progress",
+	)
+	runCmd.Flags().BoolVarP(
+		&runOptions.verbose,
+		"verbose",
+		"v",
+		false,
+		"print the number of directives of every file and of analyzed files",
+	)
+	runCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	runCmd.Flags().StringVar(
+		&runOptions.severityThreshold,
+		"severity-threshold",
+		"hint",
+		"least severe findings to report, one of: error, warning, info, hint",
+	)
+	runCmd.Flags().IntVar(
+		&runOptions.maxWarnings,
+		"max-warnings",
+		-1,
+		"exit with status 1 if more warnings are reported, negative to allow any number of warnings",
+	)
+	runCmd.Flags().StringVar(
+		&runOptions.format,
+		"format",
+		report.FormatPretty,
+		"format of the findings, one of: "+strings.Join(report.Formats(), ", ")+", defaults to github in GitHub Actions",
+	)
+	runCmd.Flags().IntVar(
+		&runOptions.width,
+		"width",
+		0,
+		"columns to wrap the lines of findings at, defaults to the width of the terminal or 80",
+	)
+	runCmd.Flags().IntVar(
+		&runOptions.context,
+		"context",
+		0,
+		"number of lines shown above and below the lines of each finding",
+	)
+	runCmd.Flags().BoolVar(
+		&runOptions.fix,
+		"fix",
+		false,
+		"apply the suggested fixes of the findings to the files",
+	)
+	runCmd.Flags().BoolVar(
+		&runOptions.fixDryRun,
+		"fix-dry-run",
+		false,
+		"print the changes the suggested fixes would make instead of applying them",
+	)
+	runCmd.MarkFlagsMutuallyExclusive("fix", "fix-dry-run")
+}
+
 var runCmd = &cobra.Command{
 	Use:   "run [OPTIONS] <path to seclang file> <additional paths to seclang files>...",
 	Short: "Runs linter on given paths",
 	Long:  runDescription,
 	Run: func(cmd *cobra.Command, args []string) {
-		if _, err := parse.ParseGlob(args...); err != nil {
+		threshold, err := parse.ParseLevelNamed(runOptions.severityThreshold)
+		if err != nil {
 			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		// annotate pull requests when running in GitHub Actions
+		if !cmd.Flags().Changed("format") && os.Getenv("GITHUB_ACTIONS") == "true" {
+			runOptions.format = report.FormatGitHub
+		}
+
+		if !slices.Contains(report.Formats(), runOptions.format) {
+			fmt.Printf("unknown format %q, expected one of: %s\n", runOptions.format, strings.Join(report.Formats(), ", "))
+			os.Exit(1)
+		}
+
+		render := parse.RenderOptions{
+			Width:   outputWidth(runOptions.width),
+			Context: runOptions.context,
+			Color:   colorOutput(),
+		}
+
+		globOptions := parse.GlobOptions{
+			Jobs:     runOptions.analyze.Jobs,
+			Reporter: progressReporter(),
+		}
+
+		files, err := parse.ParseGlobWith(globOptions, args...)
+
+		// fixing errors which stop files from parsing lets
+		// the fixed files be analyzed like the others
+		if fixed := fixParseErrors(err); runOptions.fix && len(fixed) > 0 {
+			if err := writeFixedFiles(fixed); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			files, err = parse.ParseGlobWith(globOptions, args...)
+		}
+
+		if err != nil {
+			printParseErrors(err, runOptions.format, render)
+
+			if runOptions.fixDryRun {
+				printFixDiffs(fixParseErrors(err))
+			}
+
+			os.Exit(1)
+		}
+
+		if runOptions.verbose {
+			fmt.Fprintf(os.Stderr, "analyzing %d files with %d jobs\n", len(files), runOptions.analyze.Jobs)
+		}
+
+		err = reportedFindings(analyze.Analyze(runOptions.analyze, files...), threshold)
+
+		switch {
+		case err == nil:
+			return
+		case runOptions.fixDryRun:
+			// nothing is fixed, so every finding is still reported
+			printFixDiffs(fixFiles(files, err))
+		case runOptions.fix:
+			fixed := fixFiles(files, err)
+			if len(fixed) == 0 {
+				break
+			}
+
+			if err := writeFixedFiles(fixed); err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			// report the findings left after fixing
+			if files, err = parseFiles(files); err != nil {
+				printParseErrors(err, runOptions.format, render)
+				os.Exit(1)
+			}
+
+			err = reportedFindings(analyze.Analyze(runOptions.analyze, files...), threshold)
+		}
+
+		if err != nil {
+			printFindings(parse.LinterErrors(err), runOptions.format, render)
+		}
+
+		if !passes(err, runOptions.maxWarnings) {
+			os.Exit(1)
 		}
 	},
 }
+
+// returns the reporter printing the progress of parsing files to
+// stderr, keeping the findings on stdout machine readable, nil if
+// the progress is not printed
+func progressReporter() parse.Reporter {
+	if runOptions.quiet {
+		return nil
+	}
+
+	return parse.ReporterFunc(func(name string, file *parse.File, err error) {
+		fmt.Fprintf(os.Stderr, "validating file %s", name)
+
+		// the error is printed along with the others
+		// once every file is parsed
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ".........failed!")
+
+			return
+		}
+
+		if runOptions.verbose {
+			fmt.Fprintf(os.Stderr, ".........success! (%d directives)\n", len(file.Directives))
+
+			return
+		}
+
+		fmt.Fprintln(os.Stderr, ".........success!")
+	})
+}
+
+// parses the given files again after they changed on disk
+func parseFiles(files []*parse.File) ([]*parse.File, error) {
+	parsed := make([]*parse.File, 0, len(files))
+
+	for _, file := range files {
+		reparsed, err := parse.ParseFile(file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		parsed = append(parsed, reparsed)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+// returns the width lines of reported errors are wrapped at: the
+// given width if it is positive, else the width of the terminal
+// printed to, else the COLUMNS environment variable, or zero
+// to wrap lines at the default width
+func outputWidth(width int) int {
+	if width > 0 {
+		return width
+	}
+
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 0
+}
+
+// reports whether the output is highlighted with ANSI colors,
+// which it is when printing to a terminal unless the NO_COLOR
+// environment variable is set, see https://no-color.org
+func colorOutput() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	info, err := os.Stdout.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -4,10 +4,13 @@ go 1.24
 
 require (
 	github.com/go-test/deep v1.1.1
+	github.com/rivo/uniseg v0.4.7
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/term v0.34.0
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.6 // indirect
+	golang.org/x/sys v0.35.0 // indirect
 )
@@ -0,0 +1,41 @@
+// Package jobs runs independent work on a bounded number of goroutines.
+package jobs
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Calls the function with every index from 0 to n, exclusive, using
+// up to the given number of workers, GOMAXPROCS when zero or less.
+// Returns once every call returned, results are usually stored by
+// the function at its index so they do not depend on scheduling.
+func Run(jobs, n int, fn func(i int)) {
+	var (
+		next = make(chan int)
+		wg   sync.WaitGroup
+	)
+
+	if jobs < 1 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+
+	for range min(jobs, n) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := range n {
+		next <- i
+	}
+
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestRun(t *testing.T) {
+	for _, jobs := range []int{0, 1, 4, 32} {
+		t.Run(fmt.Sprintf("POSITIVE - %d jobs call every index once", jobs), func(t *testing.T) {
+			var (
+				calls = make([]int32, 20)
+				want  = make([]int32, 20)
+			)
+
+			for i := range want {
+				want[i] = 1
+			}
+
+			Run(jobs, len(calls), func(i int) {
+				atomic.AddInt32(&calls[i], 1)
+			})
+
+			if diff := deep.Equal(calls, want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	t.Run("POSITIVE - no work", func(t *testing.T) {
+		Run(4, 0, func(i int) {
+			t.Errorf("unexpected call with index %d", i)
+		})
+	})
+}
@@ -0,0 +1,184 @@
+package analyze
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/bak-minsu/seclang-linter/internal/jobs"
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// options changing how the files are analyzed
+type Options struct {
+	// directory data files of operators such as @pmFromFile are
+	// resolved against, empty to resolve them against the
+	// directory of the rule file
+	DataRoot string
+
+	// number of files analyzed at once by checks looking
+	// at a single file, GOMAXPROCS when zero or less
+	Jobs int
+}
+
+// a check analyzes the ruleset and returns its findings
+type check func(rs *ruleset) []*parse.LinterError
+
+// a check along with the rules it relates
+type scopedCheck struct {
+	check check
+
+	// reports whether the check only relates rules of the same
+	// file, so it can analyze every file on its own
+	perFile bool
+}
+
+// returns all checks run by Analyze
+func checks() []scopedCheck {
+	return []scopedCheck{
+		{check: checkTransformations, perFile: true},
+		{check: checkDisruptiveActions, perFile: true},
+		{check: checkVariablePhases, perFile: true},
+		{check: checkRuleIDs},
+		{check: checkMarkers},
+		{check: checkExclusions},
+		{check: checkCtl},
+		{check: checkSetvar, perFile: true},
+		{check: checkMacros},
+		{check: checkOperators, perFile: true},
+		{check: checkDataFiles},
+		{check: checkLineEndings, perFile: true},
+	}
+}
+
+// Analyzes the given parsed files, expected in load order,
+// and returns all findings joined into a single error, sorted
+// by the load order of their files and then by offset.
+// Checks of single files analyze files concurrently, checks
+// relating files run once every file is analyzed.
+func Analyze(options Options, files ...*parse.File) error {
+	rs, findings := newRuleset(files)
+	rs.options = options
+
+	checks := checks()
+
+	results := rs.analyzeFiles(options.Jobs, checks)
+
+	for i, scoped := range checks {
+		if !scoped.perFile {
+			results[i] = scoped.check(rs)
+		}
+	}
+
+	for _, result := range results {
+		findings = append(findings, result...)
+	}
+
+	rs.sortFindings(findings)
+
+	if len(findings) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(findings))
+	for _, finding := range findings {
+		errs = append(errs, finding)
+	}
+
+	return errors.Join(errs...)
+}
+
+// runs the checks of single files on every file using up to the
+// given number of workers, returning the findings of each check
+// at its index
+func (rs *ruleset) analyzeFiles(workers int, checks []scopedCheck) [][]*parse.LinterError {
+	var (
+		fileRulesets = rs.fileRulesets()
+		fileResults  = make([][][]*parse.LinterError, len(fileRulesets))
+	)
+
+	jobs.Run(workers, len(fileRulesets), func(i int) {
+		fileResults[i] = make([][]*parse.LinterError, len(checks))
+
+		for j, scoped := range checks {
+			if scoped.perFile {
+				fileResults[i][j] = scoped.check(fileRulesets[i])
+			}
+		}
+	})
+
+	// merge in file order so findings do not depend on scheduling
+	results := make([][]*parse.LinterError, len(checks))
+
+	for _, fileResult := range fileResults {
+		for j, found := range fileResult {
+			results[j] = append(results[j], found...)
+		}
+	}
+
+	return results
+}
+
+// sorts the findings by the load order of their files, then by
+// offset. Files are told apart by name, the findings of files read
+// by the rules such as data files follow in order of appearance.
+func (rs *ruleset) sortFindings(findings []*parse.LinterError) {
+	order := map[string]int{}
+
+	for _, file := range rs.files {
+		if _, ok := order[file.Name()]; !ok {
+			order[file.Name()] = len(order)
+		}
+	}
+
+	for _, finding := range findings {
+		if _, ok := order[finding.File]; !ok {
+			order[finding.File] = len(order)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b *parse.LinterError) int {
+		return cmp.Or(cmp.Compare(order[a.File], order[b.File]), cmp.Compare(a.Offset, b.Offset))
+	})
+}
+
+// creates a linter error of the given kind pointing at the given range of the file
+func newLinterError(file *parse.File, kind *parse.Kind, level, offset, distance int, message string) *parse.LinterError {
+	return &parse.LinterError{
+		File:       file.Name(),
+		Kind:       kind,
+		Message:    message,
+		ParseLevel: level,
+		Offset:     offset,
+		Distance:   distance,
+		Contents:   string(file.Contents()),
+	}
+}
+
+// creates a linter error of the given kind pointing at the given action
+func actionError(file *parse.File, kind *parse.Kind, level int, action *parse.Action, message string) *parse.LinterError {
+	return newLinterError(file, kind, level, action.Offset, action.Len(), message)
+}
+
+// creates a fix removing the action from the action list
+// it belongs to, along with the comma separating it
+func removeActionFix(actions []*parse.Action, action *parse.Action, message string) *parse.SuggestedFix {
+	i := slices.Index(actions, action)
+	if i == -1 {
+		return nil
+	}
+
+	start, end := action.Offset, action.Offset+action.Len()
+
+	switch {
+	case i+1 < len(actions):
+		end = actions[i+1].Offset
+	case i > 0:
+		start = actions[i-1].Offset + actions[i-1].Len()
+	}
+
+	return &parse.SuggestedFix{
+		Message: message,
+		Edits:   []parse.TextEdit{{Offset: start, Distance: end - start}},
+	}
+}
@@ -0,0 +1,349 @@
+package analyze
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/go-test/deep"
+)
+
+// represents a finding as seen by the tests
+type finding struct {
+	// message of the finding
+	Message string
+
+	// content the finding points at
+	Lexeme string
+}
+
+// parses the given file contents in load order and
+// returns the findings of the given check
+func runCheck(t *testing.T, c check, contents ...string) []finding {
+	t.Helper()
+
+	return runCheckWithOptions(t, c, Options{}, contents...)
+}
+
+// runs the check like runCheck, analyzing with the given options
+func runCheckWithOptions(t *testing.T, c check, options Options, contents ...string) []finding {
+	t.Helper()
+
+	files := make([]*parse.File, 0, len(contents))
+
+	for _, content := range contents {
+		file, err := parse.Parse([]byte(content))
+		if err != nil {
+			t.Fatalf("could not parse test contents: %v", err)
+		}
+
+		files = append(files, file)
+	}
+
+	rs, errs := newRuleset(files)
+	if len(errs) > 0 {
+		t.Fatalf("could not build ruleset: %v", errs)
+	}
+
+	rs.options = options
+
+	var findings []finding
+
+	for _, linterError := range c(rs) {
+		findings = append(findings, finding{
+			Message: linterError.Message,
+			Lexeme:  linterError.Contents[linterError.Offset:linterError.OffsetEnd()],
+		})
+	}
+
+	return findings
+}
+
+func TestRulesetIncludeOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		check check
+		fsys  fstest.MapFS
+		want  []finding
+	}{
+		{
+			name:  "POSITIVE - exclusion after the Include of the rule",
+			check: checkExclusions,
+			fsys: fstest.MapFS{
+				"main.conf":    {Data: []byte("Include rules/*.conf\nSecRuleRemoveById 942100")},
+				"rules/a.conf": {Data: []byte(`SecRule ARGS "@rx foo" "id:942100,phase:2,deny"`)},
+			},
+		},
+		{
+			name:  "NEGATIVE - exclusion before the Include of the rule",
+			check: checkExclusions,
+			fsys: fstest.MapFS{
+				"main.conf":    {Data: []byte("SecRuleRemoveById 942100\nInclude rules/*.conf")},
+				"rules/a.conf": {Data: []byte(`SecRule ARGS "@rx foo" "id:942100,phase:2,deny"`)},
+			},
+			want: []finding{
+				{
+					Message: `SecRuleRemoveById "942100" is declared before the rules it matches, it has no effect on them`,
+					Lexeme:  "942100",
+				},
+			},
+		},
+		{
+			name:  "POSITIVE - marker after the Include of the rule jumping to it",
+			check: checkMarkers,
+			fsys: fstest.MapFS{
+				"main.conf":    {Data: []byte("Include rules/*.conf\nSecMarker END")},
+				"rules/a.conf": {Data: []byte(`SecAction "id:1,phase:1,pass,nolog,skipAfter:END"`)},
+			},
+		},
+		{
+			name:  "NEGATIVE - section of a marker starting at a marker of an included file",
+			check: checkMarkers,
+			fsys: fstest.MapFS{
+				"main.conf": {Data: []byte("Include rules/*.conf\n" +
+					`SecRule ARGS "@rx foo" "id:2,phase:1,pass"` + "\n" +
+					"SecMarker END")},
+				"rules/a.conf": {Data: []byte(`SecAction "id:1,phase:2,pass,nolog,skipAfter:END"` + "\n" +
+					"SecMarker INCLUDED")},
+			},
+			want: []finding{
+				{
+					Message: `skipAfter jumps to marker "END" ending a section without phase 2 rules`,
+					Lexeme:  "skipAfter:END",
+				},
+				{
+					Message: `no skipAfter action jumps to marker "INCLUDED"`,
+					Lexeme:  "SecMarker INCLUDED",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := parse.ParseFS(tt.fsys, "main.conf")
+			if err != nil {
+				t.Fatalf("could not parse test files: %v", err)
+			}
+
+			rs, errs := newRuleset(files)
+			if len(errs) > 0 {
+				t.Fatalf("could not build ruleset: %v", errs)
+			}
+
+			var got []finding
+
+			for _, linterError := range tt.check(rs) {
+				got = append(got, finding{
+					Message: linterError.Message,
+					Lexeme:  linterError.Contents[linterError.Offset:linterError.OffsetEnd()],
+				})
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  bool
+		wantKind *parse.Kind
+	}{
+		{
+			name:     "POSITIVE - valid rule",
+			contents: `SecRule ARGS "@rx foo" "id:1,phase:2,deny,t:none,t:lowercase"`,
+		},
+		{
+			name:     "NEGATIVE - invalid action list",
+			contents: `SecRule ARGS "@rx foo" "id:1,phase:2,deny,"`,
+			wantErr:  true,
+			wantKind: parse.ErrEmptyAction,
+		},
+		{
+			name:     "NEGATIVE - finding from a check",
+			contents: `SecRule ARGS "@rx foo" "id:1,phase:2,deny,t:bogus"`,
+			wantErr:  true,
+			wantKind: ErrUnknownTransformation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parse.Parse([]byte(tt.contents))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			err = Analyze(Options{}, file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Analyze() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("Analyze() error = %v, want kind %v", err, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestAnalyzeJobs(t *testing.T) {
+	var files []*parse.File
+
+	for i, contents := range []string{
+		`SecRule ARGS "@rx foo" "id:1,phase:2,deny,t:bogus"`,
+		`SecMarker END`,
+		`SecRule ARGS "@eq foo" "id:2,phase:2,deny,t:unknown"`,
+	} {
+		file, err := parse.ParseReader(fmt.Sprintf("%d.conf", i), strings.NewReader(contents))
+		if err != nil {
+			t.Fatalf("could not parse test contents: %v", err)
+		}
+
+		files = append(files, file)
+	}
+
+	// findings are listed in file order and then by offset,
+	// whatever the number of files analyzed at once
+	want := []string{
+		`unknown transformation "bogus"`,
+		`no skipAfter action jumps to marker "END"`,
+		`invalid argument "foo" for @eq, expected an integer or a macro`,
+		`unknown transformation "unknown"`,
+	}
+
+	for _, jobs := range []int{0, 1, 2, 8} {
+		t.Run(fmt.Sprintf("POSITIVE - %d jobs", jobs), func(t *testing.T) {
+			var got []string
+			for _, finding := range parse.LinterErrors(Analyze(Options{Jobs: jobs}, files...)) {
+				got = append(got, finding.Message)
+			}
+
+			if diff := deep.Equal(got, want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAnalyzeLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     map[string]int
+	}{
+		{
+			name:     "POSITIVE - mistakes are errors and warnings",
+			contents: `SecRule ARGS "@rx foo" "id:1,phase:2,deny,t:none,t:bogus,t:lowercase,t:uppercase"`,
+			want: map[string]int{
+				`unknown transformation "bogus"`:                                            parse.ParseLevelError,
+				"transformation t:lowercase has no effect, it is overridden by t:uppercase": parse.ParseLevelWarning,
+			},
+		},
+		{
+			name:     "POSITIVE - redundant transformations are info",
+			contents: `SecRule ARGS "@rx foo" "id:1,phase:2,deny,t:none,t:lowercase,t:lowercase"`,
+			want: map[string]int{
+				"duplicate transformation t:lowercase has no effect": parse.ParseLevelInfo,
+			},
+		},
+		{
+			name:     "POSITIVE - mixed line endings are hints",
+			contents: "SecMarker A\nSecMarker B\r\nSecMarker C\n",
+			want: map[string]int{
+				`no skipAfter action jumps to marker "A"`:                               parse.ParseLevelWarning,
+				`no skipAfter action jumps to marker "B"`:                               parse.ParseLevelWarning,
+				`no skipAfter action jumps to marker "C"`:                               parse.ParseLevelWarning,
+				`line ends with "\r\n" while the other lines of the file end with "\n"`: parse.ParseLevelHint,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parse.ParseReader("rules.conf", strings.NewReader(tt.contents))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			got := map[string]int{}
+			for _, finding := range parse.LinterErrors(Analyze(Options{}, file)) {
+				got[finding.Message] = finding.ParseLevel
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    check
+		contents string
+		want     string
+	}{
+		{
+			name:     "POSITIVE - insert t:none before inherited transformations",
+			check:    checkTransformations,
+			contents: "SecDefaultAction \"phase:2,log,pass,t:urlDecode\"\nSecRule ARGS \"@rx a\" \"id:1,t:lowercase\"",
+			want:     "SecDefaultAction \"phase:2,log,pass,t:urlDecode\"\nSecRule ARGS \"@rx a\" \"id:1,t:none,t:lowercase\"",
+		},
+		{
+			name:     "POSITIVE - remove duplicate transformation",
+			check:    checkTransformations,
+			contents: `SecRule ARGS "@rx a" "id:1,t:none,t:lowercase,t:lowercase,deny"`,
+			want:     `SecRule ARGS "@rx a" "id:1,t:none,t:lowercase,deny"`,
+		},
+		{
+			name:     "POSITIVE - remove last action on a continuation line",
+			check:    checkTransformations,
+			contents: "SecRule ARGS \"@rx a\" \\\n    \"id:1,\\\n    t:none,t:trimLeft,\\\n    t:trim\"",
+			want:     "SecRule ARGS \"@rx a\" \\\n    \"id:1,\\\n    t:none,t:trim\"",
+		},
+		{
+			name:     "POSITIVE - remove unused disruptive action",
+			check:    checkDisruptiveActions,
+			contents: `SecRule ARGS "@rx a" "id:1,pass,deny"`,
+			want:     `SecRule ARGS "@rx a" "id:1,deny"`,
+		},
+		{
+			name:     "POSITIVE - remove whitespace after a continuation and mixed line ending",
+			check:    checkLineEndings,
+			contents: "SecRule ARGS \"@rx a\" \"id:1,\\  \n    deny\"\r\nSecMarker A\r\n",
+			want:     "SecRule ARGS \"@rx a\" \"id:1,\\\r\n    deny\"\r\nSecMarker A\r\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parse.Parse([]byte(tt.contents))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			rs, errs := newRuleset([]*parse.File{file})
+			if len(errs) > 0 {
+				t.Fatalf("could not build ruleset: %v", errs)
+			}
+
+			var fixes []*parse.SuggestedFix
+
+			for _, linterError := range tt.check(rs) {
+				if linterError.Fix != nil {
+					fixes = append(fixes, linterError.Fix)
+				}
+			}
+
+			got, _ := parse.ApplyFixes(file.Contents(), fixes)
+			if diff := deep.Equal(string(got), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
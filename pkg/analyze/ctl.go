@@ -0,0 +1,247 @@
+package analyze
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// validates the argument of a ctl option
+type ctlValidator func(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError
+
+// represents a ctl option supported by Coraza
+type ctlOption struct {
+	// validator of the option argument
+	validate ctlValidator
+
+	// reports whether the option expects a target expression
+	target bool
+}
+
+// ctl options supported by Coraza, keyed by lowercase name
+var ctlOptions = map[string]ctlOption{
+	"auditengine":              {validate: ctlOneOf("On", "Off", "RelevantOnly")},
+	"auditlogparts":            {validate: ctlMatches(regexp.MustCompile(`^[+-]?[A-KZ]+$`), "audit log parts such as ABIJDEFHZ")},
+	"debugloglevel":            {validate: ctlInteger(0, 9)},
+	"forcerequestbodyvariable": {validate: ctlOneOf("On", "Off")},
+	"requestbodyaccess":        {validate: ctlOneOf("On", "Off")},
+	"requestbodylimit":         {validate: ctlInteger(0, -1)},
+	"requestbodyprocessor":     {validate: ctlOneOf("URLENCODED", "MULTIPART", "XML", "JSON", "RAW")},
+	"responsebodyaccess":       {validate: ctlOneOf("On", "Off")},
+	"responsebodylimit":        {validate: ctlInteger(0, -1)},
+	"responsebodyprocessor":    {validate: ctlOneOf("XML", "JSON")},
+	"ruleengine":               {validate: ctlOneOf("On", "Off", "DetectionOnly")},
+	"ruleremovebyid":           {validate: ctlRuleID},
+	"ruleremovebymsg":          {validate: ctlRuleMsg},
+	"ruleremovebytag":          {validate: ctlRuleTag},
+	"ruleremovetargetbyid":     {validate: ctlRuleID, target: true},
+	"ruleremovetargetbymsg":    {validate: ctlRuleMsg, target: true},
+	"ruleremovetargetbytag":    {validate: ctlRuleTag, target: true},
+}
+
+// validates the option, argument and target of every ctl action
+func checkCtl(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		for _, action := range r.actionsNamed("ctl") {
+			ctl, err := parse.ParseCtl(r.file.Contents(), action)
+			if err != nil {
+				findings = appendLinterError(findings, r.file, err)
+
+				continue
+			}
+
+			findings = append(findings, checkCtlAction(rs, r, action, ctl)...)
+		}
+	}
+
+	return findings
+}
+
+// validates a single parsed ctl action of the rule
+func checkCtlAction(rs *ruleset, r *rule, action *parse.Action, ctl *parse.Ctl) []*parse.LinterError {
+	option, ok := ctlOptions[strings.ToLower(ctl.Option)]
+	if !ok {
+		return []*parse.LinterError{
+			newLinterError(
+				r.file,
+				ErrUnknownCtlOption,
+				parse.ParseLevelError,
+				ctl.Offset,
+				len(ctl.Option),
+				fmt.Sprintf("unknown ctl option %q", ctl.Option),
+			),
+		}
+	}
+
+	findings := option.validate(rs, r, ctl)
+
+	switch {
+	case option.target && ctl.Target == "":
+		findings = append(findings, actionError(
+			r.file,
+			ErrMissingCtlTarget,
+			parse.ParseLevelError,
+			action,
+			fmt.Sprintf("ctl:%s expects a target after ';', ex. %s=<argument>;ARGS:name", ctl.Option, ctl.Option),
+		))
+	case option.target:
+		target := &parse.Option{
+			Lexeme: ctl.Target,
+			Offset: ctl.TargetOffset,
+		}
+
+		if _, err := parse.ParseVariables(r.file.Contents(), target); err != nil {
+			findings = appendLinterError(findings, r.file, err)
+		}
+	case ctl.Target != "":
+		findings = append(findings, newLinterError(
+			r.file,
+			ErrUnexpectedCtlTarget,
+			parse.ParseLevelError,
+			ctl.TargetOffset,
+			len(ctl.Target),
+			fmt.Sprintf("ctl:%s does not take a target", ctl.Option),
+		))
+	}
+
+	if strings.EqualFold(ctl.Option, "ruleEngine") &&
+		strings.EqualFold(ctl.Argument, "Off") &&
+		len(r.chainStart().chain) == 0 {
+		findings = append(findings, actionError(
+			r.file,
+			ErrCtlRuleEngineOff,
+			parse.ParseLevelWarning,
+			action,
+			"ctl:ruleEngine=Off disables every rule for all transactions reaching this rule, narrow it with a chain",
+		))
+	}
+
+	return findings
+}
+
+// returns a validator accepting the given values, case insensitive
+func ctlOneOf(values ...string) ctlValidator {
+	return func(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+		for _, value := range values {
+			if strings.EqualFold(ctl.Argument, value) {
+				return nil
+			}
+		}
+
+		return ctlArgumentError(r, ctl, fmt.Sprintf(
+			"invalid argument %q for ctl:%s, expected one of %s",
+			ctl.Argument,
+			ctl.Option,
+			strings.Join(values, ", "),
+		))
+	}
+}
+
+// returns a validator accepting integers within the given bounds,
+// a negative maximum leaves the integer unbounded
+func ctlInteger(minimum, maximum int) ctlValidator {
+	return func(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+		value, err := strconv.Atoi(ctl.Argument)
+		if err == nil && value >= minimum && (maximum < 0 || value <= maximum) {
+			return nil
+		}
+
+		expected := fmt.Sprintf("an integer of at least %d", minimum)
+		if maximum >= 0 {
+			expected = fmt.Sprintf("an integer from %d to %d", minimum, maximum)
+		}
+
+		return ctlArgumentError(r, ctl, fmt.Sprintf(
+			"invalid argument %q for ctl:%s, expected %s",
+			ctl.Argument,
+			ctl.Option,
+			expected,
+		))
+	}
+}
+
+// returns a validator accepting arguments matching the pattern
+func ctlMatches(pattern *regexp.Regexp, expected string) ctlValidator {
+	return func(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+		if pattern.MatchString(ctl.Argument) {
+			return nil
+		}
+
+		return ctlArgumentError(r, ctl, fmt.Sprintf(
+			"invalid argument %q for ctl:%s, expected %s",
+			ctl.Argument,
+			ctl.Option,
+			expected,
+		))
+	}
+}
+
+// validates that the argument is a rule ID or ID range
+// matching at least one rule
+func ctlRuleID(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+	ids, ok := parse.ParseIDRange(ctl.Argument)
+	if !ok {
+		return ctlArgumentError(r, ctl, fmt.Sprintf(
+			"invalid rule ID or ID range %q for ctl:%s",
+			ctl.Argument,
+			ctl.Option,
+		))
+	}
+
+	return ctlMatchedRules(r, ctl, rs.rulesWithID(ids))
+}
+
+// validates that the argument is a tag of at least one rule
+func ctlRuleTag(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+	return ctlMatchedRules(r, ctl, rs.rulesTagged(ctl.Argument))
+}
+
+// validates that the argument is the message of at least one rule
+func ctlRuleMsg(rs *ruleset, r *rule, ctl *parse.Ctl) []*parse.LinterError {
+	var matched []*rule
+
+	for _, other := range rs.rules {
+		if msg := other.lastAction("msg"); msg != nil && msg.Value == ctl.Argument {
+			matched = append(matched, other)
+		}
+	}
+
+	return ctlMatchedRules(r, ctl, matched)
+}
+
+// reports the ctl action if it matches no rule
+func ctlMatchedRules(r *rule, ctl *parse.Ctl, matched []*rule) []*parse.LinterError {
+	if len(matched) > 0 {
+		return nil
+	}
+
+	return []*parse.LinterError{
+		newLinterError(
+			r.file,
+			ErrUnmatchedCtl,
+			parse.ParseLevelWarning,
+			ctl.ArgumentOffset,
+			max(len(ctl.Argument), 1),
+			fmt.Sprintf("ctl:%s %q matches no rule", ctl.Option, ctl.Argument),
+		),
+	}
+}
+
+// creates an error pointing at the ctl argument
+func ctlArgumentError(r *rule, ctl *parse.Ctl, message string) []*parse.LinterError {
+	return []*parse.LinterError{
+		newLinterError(
+			r.file,
+			ErrInvalidCtlArgument,
+			parse.ParseLevelError,
+			ctl.ArgumentOffset,
+			max(len(ctl.Argument), 1),
+			message,
+		),
+	}
+}
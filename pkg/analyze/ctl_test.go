@@ -0,0 +1,120 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckCtl(t *testing.T) {
+	rules := `SecRule ARGS "@rx foo" "id:942100,phase:2,deny,msg:'SQL Injection',tag:'attack-sqli'"`
+
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - valid ctl options",
+			contents: []string{
+				`SecRule REQUEST_URI "@beginsWith /login" "id:1,phase:1,pass,nolog,` +
+					`ctl:ruleRemoveTargetById=942100;ARGS:password,` +
+					`ctl:ruleRemoveByTag=attack-sqli,` +
+					`ctl:ruleRemoveByMsg=SQL Injection,` +
+					`ctl:requestBodyProcessor=JSON,` +
+					`ctl:auditLogParts=+E"`,
+				rules,
+			},
+		},
+		{
+			name: "POSITIVE - ruleEngine=Off narrowed by a chain",
+			contents: []string{
+				`SecRule REQUEST_URI "@beginsWith /health" "id:1,phase:1,pass,nolog,ctl:ruleEngine=Off,chain"` + "\n" +
+					`SecRule REMOTE_ADDR "@ipMatch 10.0.0.0/8"`,
+			},
+		},
+		{
+			name: "NEGATIVE - unknown option",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,ctl:ruleRemoveByIds=942100"`,
+			},
+			want: []finding{
+				{
+					Message: `unknown ctl option "ruleRemoveByIds"`,
+					Lexeme:  "ruleRemoveByIds",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - invalid argument",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,ctl:requestBodyAccess=Yes"`,
+			},
+			want: []finding{
+				{
+					Message: `invalid argument "Yes" for ctl:requestBodyAccess, expected one of On, Off`,
+					Lexeme:  "Yes",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - removal matching no rule",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,ctl:ruleRemoveById=941100"`,
+				rules,
+			},
+			want: []finding{
+				{
+					Message: `ctl:ruleRemoveById "941100" matches no rule`,
+					Lexeme:  "941100",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - target removal without target",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,ctl:ruleRemoveTargetByTag=attack-sqli"`,
+				rules,
+			},
+			want: []finding{
+				{
+					Message: "ctl:ruleRemoveTargetByTag expects a target after ';', ex. ruleRemoveTargetByTag=<argument>;ARGS:name",
+					Lexeme:  "ctl:ruleRemoveTargetByTag=attack-sqli",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - target removal with invalid target",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,ctl:ruleRemoveTargetById=942100;!:password"`,
+				rules,
+			},
+			want: []finding{
+				{
+					Message: "expected variable name in target list",
+					Lexeme:  "!:password",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - ruleEngine=Off without a chain",
+			contents: []string{
+				`SecRule REQUEST_URI "@beginsWith /health" "id:1,phase:1,pass,nolog,ctl:ruleEngine=Off"`,
+			},
+			want: []finding{
+				{
+					Message: "ctl:ruleEngine=Off disables every rule for all transactions reaching this rule, narrow it with a chain",
+					Lexeme:  "ctl:ruleEngine=Off",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkCtl, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package analyze
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// operators reading their patterns from a data file, keyed by
+// lowercase name, reporting whether entries are IP addresses
+var dataFileOperators = map[string]bool{
+	"pmfromfile":      false,
+	"pmf":             false,
+	"ipmatchfromfile": true,
+	"ipmatchf":        true,
+}
+
+// represents a data file read by an operator
+type dataFile struct {
+	// path the file was read from
+	path string
+
+	// contents of the file
+	contents []byte
+
+	// reports whether entries are IP addresses or CIDRs
+	ip bool
+}
+
+// resolves the data files of @pmFromFile and @ipMatchFromFile
+// operators, reports missing files and lints their contents
+func checkDataFiles(rs *ruleset) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		linted   = map[string]bool{}
+	)
+
+	for _, r := range rs.rules {
+		if r.operator == nil {
+			continue
+		}
+
+		ip, ok := dataFileOperators[strings.ToLower(r.operator.Name)]
+		if !ok {
+			continue
+		}
+
+		if r.operator.Argument == "" {
+			findings = append(findings, newLinterError(
+				r.file,
+				ErrMissingDataFilePath,
+				parse.ParseLevelError,
+				r.operator.Offset,
+				len(r.operator.Name)+1,
+				fmt.Sprintf("@%s expects the path of a data file", r.operator.Name),
+			))
+
+			continue
+		}
+
+		path := rs.dataFilePath(r.file, r.operator.Argument)
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			message := fmt.Sprintf("could not read data file %q: %v", path, errors.Unwrap(err))
+			if errors.Is(err, fs.ErrNotExist) {
+				message = fmt.Sprintf("data file %q does not exist", path)
+			}
+
+			findings = append(findings, newLinterError(
+				r.file,
+				ErrUnreadableDataFile,
+				parse.ParseLevelError,
+				r.operator.ArgumentOffset,
+				len(r.operator.Argument),
+				message,
+			))
+
+			continue
+		}
+
+		if linted[path] {
+			continue
+		}
+
+		linted[path] = true
+
+		findings = append(findings, lintDataFile(&dataFile{path: path, contents: contents, ip: ip})...)
+	}
+
+	return findings
+}
+
+// returns the path of the data file named by an operator of
+// the given file, relative to the data root if one is set
+func (rs *ruleset) dataFilePath(file *parse.File, name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
+	if rs.options.DataRoot != "" {
+		return filepath.Join(rs.options.DataRoot, name)
+	}
+
+	return filepath.Join(filepath.Dir(file.Name()), name)
+}
+
+// reports empty lines, duplicate entries and, for IP data files,
+// entries which are not IPs or CIDRs or are followed by a comment
+func lintDataFile(data *dataFile) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		seen     = map[string]int{}
+		text     = string(data.contents)
+	)
+
+	for offset, number := 0, 1; offset < len(text); number++ {
+		end := strings.IndexByte(text[offset:], '\n')
+		if end == -1 {
+			end = len(text) - offset
+		}
+
+		line := text[offset : offset+end]
+		start := offset
+
+		offset += end + 1
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			findings = append(findings, dataFileError(
+				data,
+				ErrEmptyDataLine,
+				parse.ParseLevelHint,
+				start,
+				max(len(line), 1),
+				"empty line in data file is ignored, remove it",
+			))
+
+			continue
+		}
+
+		if trimmed[0] == '#' {
+			continue
+		}
+
+		entryOffset := start + strings.Index(line, trimmed)
+
+		key := strings.ToLower(trimmed)
+
+		if data.ip {
+			network, ok := parseIPEntry(trimmed)
+			if !ok {
+				// phrases may contain "#", but an address followed
+				// by one can only be meant as a trailing comment
+				if entry, _, found := strings.Cut(trimmed, " #"); found {
+					if _, ok := parseIPEntry(strings.TrimSpace(entry)); ok {
+						comment := len(entry) + 1
+
+						findings = append(findings, dataFileError(
+							data,
+							ErrMisplacedComment,
+							parse.ParseLevelError,
+							entryOffset+comment,
+							len(trimmed)-comment,
+							"comments must start at the beginning of a line, this text is part of the entry",
+						))
+
+						continue
+					}
+				}
+
+				findings = append(findings, dataFileError(
+					data,
+					ErrInvalidDataEntry,
+					parse.ParseLevelError,
+					entryOffset,
+					len(trimmed),
+					fmt.Sprintf("invalid IP address or CIDR %q", trimmed),
+				))
+
+				continue
+			}
+
+			key = network.String()
+		}
+
+		if first, ok := seen[key]; ok {
+			findings = append(findings, dataFileError(
+				data,
+				ErrDuplicateDataEntry,
+				parse.ParseLevelInfo,
+				entryOffset,
+				len(trimmed),
+				fmt.Sprintf("duplicate entry %q, first listed on line %d", trimmed, first),
+			))
+
+			continue
+		}
+
+		seen[key] = number
+	}
+
+	return findings
+}
+
+// parses an IP address or CIDR the way Coraza does, addresses
+// without a prefix length match only themselves
+func parseIPEntry(entry string) (*net.IPNet, bool) {
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+
+		return network, err == nil
+	}
+
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, false
+	}
+
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, true
+}
+
+// creates a linter error of the given kind pointing at the given range of the data file
+func dataFileError(data *dataFile, kind *parse.Kind, level, offset, distance int, message string) *parse.LinterError {
+	return &parse.LinterError{
+		File:       data.path,
+		Kind:       kind,
+		Message:    message,
+		ParseLevel: level,
+		Offset:     offset,
+		Distance:   distance,
+		Contents:   string(data.contents),
+	}
+}
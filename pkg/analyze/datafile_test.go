@@ -0,0 +1,145 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckDataFiles(t *testing.T) {
+	root := t.TempDir()
+
+	dataFiles := map[string]string{
+		"valid.data":        "# scanners\nnikto\nsqlmap\n",
+		"empty-lines.data":  "nikto\n\n  \nsqlmap\n",
+		"hash.data":         "Breakpoint exists at #\nargument #2\n",
+		"comment-ip.data":   "10.0.0.1 # office\n",
+		"duplicate.data":    "nikto\nsqlmap\nNikto\n",
+		"valid-ips.data":    "10.0.0.0/8\n192.168.1.1\n::1\n",
+		"invalid-ips.data":  "10.0.0.0/33\n192.168.1.256\n",
+		"duplicate-ip.data": "192.168.1.1\n192.168.1.1/32\n",
+	}
+
+	for name, contents := range dataFiles {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(contents), 0o600); err != nil {
+			t.Fatalf("could not write data file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		contents string
+		want     []finding
+	}{
+		{
+			name:     "POSITIVE - valid phrase data file",
+			contents: `SecRule REQUEST_HEADERS:User-Agent "@pmFromFile valid.data" "id:1,phase:1,deny"`,
+		},
+		{
+			name:     "POSITIVE - valid IP data file",
+			contents: `SecRule REMOTE_ADDR "@ipMatchFromFile valid-ips.data" "id:1,phase:1,deny"`,
+		},
+		{
+			name:     "POSITIVE - phrases containing a hash",
+			contents: `SecRule RESPONSE_BODY "@pmFromFile hash.data" "id:1,phase:4,deny"`,
+		},
+		{
+			name:     "POSITIVE - data file read by multiple rules is linted once",
+			contents: `SecRule ARGS "@pmf duplicate.data" "id:1,phase:2,deny"` + "\n" + `SecRule ARGS_NAMES "@pmf duplicate.data" "id:2,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `duplicate entry "Nikto", first listed on line 1`,
+					Lexeme:  "Nikto",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - missing data file",
+			contents: `SecRule ARGS "@pmFromFile missing.data" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `data file "` + filepath.Join(root, "missing.data") + `" does not exist`,
+					Lexeme:  "missing.data",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - missing data file path",
+			contents: `SecRule ARGS "@pmFromFile" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: "@pmFromFile expects the path of a data file",
+					Lexeme:  "@pmFromFile",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - empty lines",
+			contents: `SecRule ARGS "@pmFromFile empty-lines.data" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: "empty line in data file is ignored, remove it",
+					Lexeme:  "\n",
+				},
+				{
+					Message: "empty line in data file is ignored, remove it",
+					Lexeme:  "  ",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - case insensitive duplicate entry",
+			contents: `SecRule ARGS "@pmFromFile duplicate.data" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `duplicate entry "Nikto", first listed on line 1`,
+					Lexeme:  "Nikto",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - invalid IP addresses and CIDRs",
+			contents: `SecRule REMOTE_ADDR "@ipMatchF invalid-ips.data" "id:1,phase:1,deny"`,
+			want: []finding{
+				{
+					Message: `invalid IP address or CIDR "10.0.0.0/33"`,
+					Lexeme:  "10.0.0.0/33",
+				},
+				{
+					Message: `invalid IP address or CIDR "192.168.1.256"`,
+					Lexeme:  "192.168.1.256",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - comment after an IP address",
+			contents: `SecRule REMOTE_ADDR "@ipMatchFromFile comment-ip.data" "id:1,phase:1,deny"`,
+			want: []finding{
+				{
+					Message: "comments must start at the beginning of a line, this text is part of the entry",
+					Lexeme:  "# office",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - duplicate IP address written as CIDR",
+			contents: `SecRule REMOTE_ADDR "@ipMatchFromFile duplicate-ip.data" "id:1,phase:1,deny"`,
+			want: []finding{
+				{
+					Message: `duplicate entry "192.168.1.1/32", first listed on line 1`,
+					Lexeme:  "192.168.1.1/32",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheckWithOptions(t, checkDataFiles, Options{DataRoot: root}, tt.contents)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// disruptive actions supported by Coraza
+var disruptiveActions = map[string]bool{
+	"allow":    true,
+	"block":    true,
+	"deny":     true,
+	"drop":     true,
+	"pass":     true,
+	"redirect": true,
+}
+
+// disruptive actions which respond with the status action's value
+var statusActions = map[string]bool{
+	"deny":     true,
+	"redirect": true,
+}
+
+// reports rules with conflicting disruptive actions, disruptive
+// actions in chained rules and unused status actions
+func checkDisruptiveActions(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		disruptive := disruptiveActionsOf(r.actions)
+
+		if r.parent != nil {
+			for _, action := range disruptive {
+				findings = append(findings, actionError(
+					r.file,
+					ErrChainedDisruptiveAction,
+					parse.ParseLevelError,
+					action,
+					fmt.Sprintf(
+						"disruptive action %q is only allowed in the rule starting the chain",
+						action.Name,
+					),
+				))
+			}
+		}
+
+		for i := 0; i+1 < len(disruptive); i++ {
+			finding := actionError(
+				r.file,
+				ErrIneffectiveDisruptiveAction,
+				parse.ParseLevelWarning,
+				disruptive[i],
+				fmt.Sprintf(
+					"disruptive action %q has no effect, only the last disruptive action %q is used",
+					disruptive[i].Name,
+					disruptive[len(disruptive)-1].Name,
+				),
+			)
+			finding.Fix = removeActionFix(r.actions, disruptive[i], "remove the unused disruptive action")
+
+			findings = append(findings, finding)
+		}
+
+		findings = append(findings, checkStatus(r)...)
+	}
+
+	return findings
+}
+
+// reports status actions of the rule which are not used
+// by the disruptive action the rule ends up with
+func checkStatus(r *rule) []*parse.LinterError {
+	statuses := r.actionsNamed("status")
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	if statusActions[effectiveDisruptiveAction(r.chainStart())] {
+		return nil
+	}
+
+	findings := make([]*parse.LinterError, 0, len(statuses))
+
+	for _, status := range statuses {
+		findings = append(findings, actionError(
+			r.file,
+			ErrIneffectiveStatus,
+			parse.ParseLevelWarning,
+			status,
+			fmt.Sprintf(
+				"status:%s has no effect without a deny or redirect disruptive action",
+				status.Value,
+			),
+		))
+	}
+
+	return findings
+}
+
+// returns the lowercase name of the disruptive action applied when
+// the rule matches, empty if the rule has no disruptive action.
+// The block action resolves to the disruptive action inherited
+// from SecDefaultAction.
+func effectiveDisruptiveAction(r *rule) string {
+	name := ""
+
+	if disruptive := disruptiveActionsOf(r.actions); len(disruptive) > 0 {
+		name = strings.ToLower(disruptive[len(disruptive)-1].Name)
+	}
+
+	if name != "" && name != "block" {
+		return name
+	}
+
+	if inherited := disruptiveActionsOf(r.defaults); len(inherited) > 0 {
+		return strings.ToLower(inherited[len(inherited)-1].Name)
+	}
+
+	return name
+}
+
+// returns the disruptive actions out of the given actions,
+// in declaration order
+func disruptiveActionsOf(actions []*parse.Action) []*parse.Action {
+	var disruptive []*parse.Action
+
+	for _, action := range actions {
+		if disruptiveActions[strings.ToLower(action.Name)] {
+			disruptive = append(disruptive, action)
+		}
+	}
+
+	return disruptive
+}
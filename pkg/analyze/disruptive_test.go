@@ -0,0 +1,89 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckDisruptiveActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - single disruptive action with status",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,deny,status:403"`,
+			},
+		},
+		{
+			name: "POSITIVE - chained rule without disruptive action",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,block,chain"` + "\n" +
+					`SecRule ARGS "@rx bar" "t:none"`,
+			},
+		},
+		{
+			name: "POSITIVE - chain ends at a rule without actions",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,deny,chain"` + "\n" +
+					`    SecRule ARGS "@rx bar"` + "\n" +
+					`SecRule ARGS "@rx baz" "id:2,phase:1,deny"`,
+			},
+		},
+		{
+			name: "POSITIVE - block inheriting deny uses status",
+			contents: []string{
+				`SecDefaultAction "phase:2,log,deny"`,
+				`SecRule ARGS "@rx foo" "id:1,phase:2,block,status:403"`,
+			},
+		},
+		{
+			name: "NEGATIVE - deny and pass",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,deny,pass"`,
+			},
+			want: []finding{
+				{
+					Message: `disruptive action "deny" has no effect, only the last disruptive action "pass" is used`,
+					Lexeme:  "deny",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - disruptive action in chained rule",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,block,chain"` + "\n" +
+					`SecRule ARGS "@rx bar" "allow"`,
+			},
+			want: []finding{
+				{
+					Message: `disruptive action "allow" is only allowed in the rule starting the chain`,
+					Lexeme:  "allow",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - status without disruptive action using it",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,pass,status:403"`,
+			},
+			want: []finding{
+				{
+					Message: "status:403 has no effect without a deny or redirect disruptive action",
+					Lexeme:  "status:403",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkDisruptiveActions, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package analyze
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// verifies that exclusion directives refer to rules
+// defined before them in load order
+func checkExclusions(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, file := range rs.files {
+		for _, directive := range file.Directives {
+			// directive names are case insensitive, CRS writes "SecRuleRemoveById"
+			switch {
+			case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleRemoveByID):
+				for _, option := range directive.Options {
+					for _, w := range option.Words() {
+						findings = append(findings, checkExcludedID(rs, file, directive, w)...)
+					}
+				}
+			case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleRemoveByTag):
+				for _, option := range directive.Options {
+					findings = append(findings, checkExcludedTag(rs, file, directive, optionWord(option))...)
+				}
+			case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleUpdateTargetByID):
+				findings = append(findings, checkUpdateTarget(rs, file, directive, checkExcludedID)...)
+			case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleUpdateTargetByTag):
+				findings = append(findings, checkUpdateTarget(rs, file, directive, checkExcludedTag)...)
+			}
+		}
+	}
+
+	return findings
+}
+
+// verifies the rule selector and target list of an update directive
+func checkUpdateTarget(
+	rs *ruleset,
+	file *parse.File,
+	directive *parse.Directive,
+	checkSelector func(*ruleset, *parse.File, *parse.Directive, parse.Word) []*parse.LinterError,
+) []*parse.LinterError {
+	if len(directive.Options) < 2 {
+		return []*parse.LinterError{
+			newLinterError(
+				file,
+				ErrInvalidExclusion,
+				parse.ParseLevelError,
+				directive.Offset,
+				directive.Len(),
+				fmt.Sprintf("%s expects a rule selector followed by a target list", directive.Lexeme),
+			),
+		}
+	}
+
+	findings := checkSelector(rs, file, directive, optionWord(directive.Options[0]))
+
+	if _, err := parse.ParseVariables(file.Contents(), directive.Options[1]); err != nil {
+		findings = appendLinterError(findings, file, err)
+	}
+
+	return findings
+}
+
+// verifies that the rule ID or ID range names rules defined
+// before the directive
+func checkExcludedID(rs *ruleset, file *parse.File, directive *parse.Directive, w parse.Word) []*parse.LinterError {
+	ids, ok := parse.ParseIDRange(w.Text)
+	if !ok {
+		return []*parse.LinterError{
+			newLinterError(
+				file,
+				ErrInvalidRuleID,
+				parse.ParseLevelError,
+				w.Offset,
+				len(w.Text),
+				fmt.Sprintf("invalid rule ID or ID range %q", w.Text),
+			),
+		}
+	}
+
+	return checkExcludedRules(rs, file, directive, w, rs.rulesWithID(ids))
+}
+
+// verifies that the tag names rules defined before the directive
+func checkExcludedTag(rs *ruleset, file *parse.File, directive *parse.Directive, w parse.Word) []*parse.LinterError {
+	return checkExcludedRules(rs, file, directive, w, rs.rulesTagged(w.Text))
+}
+
+// reports the directive if none of the matched rules
+// are defined before it
+func checkExcludedRules(rs *ruleset, file *parse.File, directive *parse.Directive, w parse.Word, matched []*rule) []*parse.LinterError {
+	position := rs.positions[directive]
+
+	for _, r := range matched {
+		if rs.positions[r.directive] < position {
+			return nil
+		}
+	}
+
+	kind, message := ErrUnmatchedExclusion, fmt.Sprintf("%s %q matches no rule", directive.Lexeme, w.Text)
+	if len(matched) > 0 {
+		kind, message = ErrEarlyExclusion, fmt.Sprintf(
+			"%s %q is declared before the rules it matches, it has no effect on them",
+			directive.Lexeme,
+			w.Text,
+		)
+	}
+
+	return []*parse.LinterError{
+		newLinterError(file, kind, parse.ParseLevelWarning, w.Offset, len(w.Text), message),
+	}
+}
+
+// returns the rules starting a chain with an ID within the range
+func (rs *ruleset) rulesWithID(ids parse.IDRange) []*rule {
+	var matched []*rule
+
+	for _, r := range rs.rules {
+		if r.parent != nil {
+			continue
+		}
+
+		if id, err := strconv.Atoi(r.id()); err == nil && ids.Contains(id) {
+			matched = append(matched, r)
+		}
+	}
+
+	return matched
+}
+
+// returns the rules starting a chain tagged with the given tag
+func (rs *ruleset) rulesTagged(tag string) []*rule {
+	var matched []*rule
+
+	for _, r := range rs.rules {
+		if r.parent != nil {
+			continue
+		}
+
+		for _, t := range r.tags() {
+			if t == tag {
+				matched = append(matched, r)
+
+				break
+			}
+		}
+	}
+
+	return matched
+}
+
+// returns the content of the option as a single word
+func optionWord(option *parse.Option) parse.Word {
+	w := parse.Word{
+		Text:   option.Content(),
+		Offset: option.Offset,
+	}
+
+	if strings.HasPrefix(option.Lexeme, `"`) && len(option.Lexeme) > 1 {
+		w.Offset++
+	}
+
+	return w
+}
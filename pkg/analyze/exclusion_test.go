@@ -0,0 +1,118 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckExclusions(t *testing.T) {
+	rules := `SecRule ARGS "@rx foo" "id:942100,phase:2,deny,tag:'attack-sqli'"` + "\n" +
+		`SecRule ARGS "@rx bar" "id:942200,phase:2,deny,tag:'attack-sqli'"`
+
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - removals after the rules",
+			contents: []string{
+				rules,
+				`SecRuleRemoveByID 942100 "942150-942250"` + "\n" +
+					`SecRuleRemoveByTag "attack-sqli"`,
+			},
+		},
+		{
+			name: "POSITIVE - target updates after the rules",
+			contents: []string{
+				rules,
+				`SecRuleUpdateTargetByID 942100 "!ARGS:password"` + "\n" +
+					`SecRuleUpdateTargetByTag attack-sqli "!REQUEST_COOKIES:/^_ga/"`,
+			},
+		},
+		{
+			name: "NEGATIVE - directive names are case insensitive",
+			contents: []string{
+				rules,
+				`SecRuleRemoveById 941100` + "\n" +
+					`secruleremovebytag attack-xss`,
+			},
+			want: []finding{
+				{
+					Message: `SecRuleRemoveById "941100" matches no rule`,
+					Lexeme:  "941100",
+				},
+				{
+					Message: `secruleremovebytag "attack-xss" matches no rule`,
+					Lexeme:  "attack-xss",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - invalid ID range",
+			contents: []string{
+				rules,
+				`SecRuleRemoveByID "942999-942100"`,
+			},
+			want: []finding{
+				{
+					Message: `invalid rule ID or ID range "942999-942100"`,
+					Lexeme:  "942999-942100",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - removals matching nothing",
+			contents: []string{
+				rules,
+				`SecRuleRemoveByID 941100` + "\n" +
+					`SecRuleRemoveByTag attack-xss`,
+			},
+			want: []finding{
+				{
+					Message: `SecRuleRemoveByID "941100" matches no rule`,
+					Lexeme:  "941100",
+				},
+				{
+					Message: `SecRuleRemoveByTag "attack-xss" matches no rule`,
+					Lexeme:  "attack-xss",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - update before the rule is defined",
+			contents: []string{
+				`SecRuleUpdateTargetByID 942100 "!ARGS:password"`,
+				rules,
+			},
+			want: []finding{
+				{
+					Message: `SecRuleUpdateTargetByID "942100" is declared before the rules it matches, it has no effect on them`,
+					Lexeme:  "942100",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - update with an invalid target list",
+			contents: []string{
+				rules,
+				`SecRuleUpdateTargetByID 942100 "ARGS||TX"`,
+			},
+			want: []finding{
+				{
+					Message: "expected variable name in target list",
+					Lexeme:  "|",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkExclusions, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package analyze
+
+import "github.com/bak-minsu/seclang-linter/pkg/parse"
+
+// kinds of findings reported by the checks of Analyze
+var (
+	// transformations
+	ErrUnknownTransformation    = &parse.Kind{Code: "SL2101", Name: "unknown transformation"}
+	ErrMisplacedReset           = &parse.Kind{Code: "SL2102", Name: "misplaced t:none"}
+	ErrInheritedTransformations = &parse.Kind{Code: "SL2103", Name: "inherited transformations"}
+	ErrDuplicateTransformation  = &parse.Kind{Code: "SL2104", Name: "duplicate transformation"}
+	ErrOverriddenTransformation = &parse.Kind{Code: "SL2105", Name: "overridden transformation"}
+
+	// disruptive actions
+	ErrChainedDisruptiveAction     = &parse.Kind{Code: "SL2201", Name: "disruptive action within a chain"}
+	ErrIneffectiveDisruptiveAction = &parse.Kind{Code: "SL2202", Name: "ineffective disruptive action"}
+	ErrIneffectiveStatus           = &parse.Kind{Code: "SL2203", Name: "ineffective status"}
+
+	// phases
+	ErrVariablePhase = &parse.Kind{Code: "SL2301", Name: "variable not populated in phase"}
+
+	// markers
+	ErrUnusedMarker     = &parse.Kind{Code: "SL2401", Name: "unused marker"}
+	ErrUndeclaredMarker = &parse.Kind{Code: "SL2402", Name: "undeclared marker"}
+	ErrBackwardSkip     = &parse.Kind{Code: "SL2403", Name: "backward skipAfter"}
+	ErrMarkerPhase      = &parse.Kind{Code: "SL2404", Name: "marker section without phase"}
+
+	// exclusions
+	ErrInvalidExclusion   = &parse.Kind{Code: "SL2501", Name: "invalid exclusion"}
+	ErrInvalidRuleID      = &parse.Kind{Code: "SL2502", Name: "invalid rule ID"}
+	ErrUnmatchedExclusion = &parse.Kind{Code: "SL2503", Name: "unmatched exclusion"}
+	ErrEarlyExclusion     = &parse.Kind{Code: "SL2504", Name: "exclusion declared before its rules"}
+
+	// ctl actions
+	ErrUnknownCtlOption    = &parse.Kind{Code: "SL2601", Name: "unknown ctl option"}
+	ErrMissingCtlTarget    = &parse.Kind{Code: "SL2602", Name: "missing ctl target"}
+	ErrUnexpectedCtlTarget = &parse.Kind{Code: "SL2603", Name: "unexpected ctl target"}
+	ErrCtlRuleEngineOff    = &parse.Kind{Code: "SL2604", Name: "unconditional ctl:ruleEngine=Off"}
+	ErrUnmatchedCtl        = &parse.Kind{Code: "SL2605", Name: "unmatched ctl"}
+	ErrInvalidCtlArgument  = &parse.Kind{Code: "SL2606", Name: "invalid ctl argument"}
+
+	// setvar actions
+	ErrUnquotedSetvarValue = &parse.Kind{Code: "SL2701", Name: "unquoted setvar value"}
+	ErrReadOnlyCollection  = &parse.Kind{Code: "SL2702", Name: "read-only collection"}
+	ErrInvalidSetvarValue  = &parse.Kind{Code: "SL2703", Name: "invalid setvar value"}
+
+	// macros
+	ErrUnknownMacroVariable = &parse.Kind{Code: "SL2801", Name: "unknown macro variable"}
+	ErrUnsetVariable        = &parse.Kind{Code: "SL2802", Name: "variable never set"}
+
+	// operators
+	ErrUnknownOperator         = &parse.Kind{Code: "SL2901", Name: "unknown operator"}
+	ErrInvalidOperatorArgument = &parse.Kind{Code: "SL2902", Name: "invalid operator argument"}
+
+	// data files
+	ErrMissingDataFilePath = &parse.Kind{Code: "SL3001", Name: "missing data file path"}
+	ErrUnreadableDataFile  = &parse.Kind{Code: "SL3002", Name: "unreadable data file"}
+	ErrEmptyDataLine       = &parse.Kind{Code: "SL3003", Name: "empty data file line"}
+	ErrMisplacedComment    = &parse.Kind{Code: "SL3004", Name: "misplaced data file comment"}
+	ErrInvalidDataEntry    = &parse.Kind{Code: "SL3005", Name: "invalid data file entry"}
+	ErrDuplicateDataEntry  = &parse.Kind{Code: "SL3006", Name: "duplicate data file entry"}
+
+	// line endings
+	ErrMixedLineEndings = &parse.Kind{Code: "SL3101", Name: "mixed line endings"}
+
+	// rule IDs
+	ErrDuplicateRuleID = &parse.Kind{Code: "SL3201", Name: "duplicate rule ID"}
+)
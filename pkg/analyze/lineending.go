@@ -0,0 +1,148 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// represents a line of a file along with its line break
+type line struct {
+	// offset of the line within the entire file
+	offset int
+
+	// text of the line without its line break
+	text string
+
+	// line break ending the line, "\n", "\r\n" or
+	// empty for the last line of the file
+	end string
+}
+
+// splits the contents into lines, keeping their line breaks
+func splitLines(contents string) []line {
+	var lines []line
+
+	for offset := 0; offset < len(contents); {
+		text, _, found := strings.Cut(contents[offset:], "\n")
+
+		l := line{offset: offset, text: text}
+		if found {
+			l.end = "\n"
+		}
+
+		if found && strings.HasSuffix(text, "\r") {
+			l.text, l.end = strings.TrimSuffix(text, "\r"), "\r\n"
+		}
+
+		lines = append(lines, l)
+		offset += len(l.text) + len(l.end)
+	}
+
+	return lines
+}
+
+// reports lines ending differently from most lines of their file
+// and whitespace written after a line continuation backslash,
+// which ends the directive instead of continuing it
+func checkLineEndings(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, file := range rs.files {
+		lines := splitLines(string(file.Contents()))
+
+		findings = append(findings, mixedLineEndings(file, lines)...)
+
+		for _, l := range lines {
+			if finding := continuationWhitespace(file, l); finding != nil {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// reports the lines not ending with the line break used by most
+// lines of the file, or by its first line if both are as common
+func mixedLineEndings(file *parse.File, lines []line) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		counts   = map[string]int{}
+		usual    string
+	)
+
+	for _, l := range lines {
+		if l.end == "" {
+			continue
+		}
+
+		if usual == "" {
+			usual = l.end
+		}
+
+		counts[l.end]++
+	}
+
+	if counts["\n"] > counts["\r\n"] {
+		usual = "\n"
+	} else if counts["\r\n"] > counts["\n"] {
+		usual = "\r\n"
+	}
+
+	for _, l := range lines {
+		if l.end == "" || l.end == usual {
+			continue
+		}
+
+		// point at the text of the line, since the
+		// line break itself can not be underlined
+		distance := len(l.text)
+		if distance == 0 {
+			distance = len(l.end)
+		}
+
+		finding := newLinterError(
+			file,
+			ErrMixedLineEndings,
+			parse.ParseLevelHint,
+			l.offset,
+			distance,
+			fmt.Sprintf("line ends with %q while the other lines of the file end with %q", l.end, usual),
+		)
+
+		finding.Fix = &parse.SuggestedFix{
+			Message: fmt.Sprintf("end the line with %q", usual),
+			Edits: []parse.TextEdit{{
+				Offset:      l.offset + len(l.text),
+				Distance:    len(l.end),
+				Replacement: usual,
+			}},
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// reports spaces and tabs following a line continuation backslash
+// at the end of the line, nil if the line does not end so
+func continuationWhitespace(file *parse.File, l line) *parse.LinterError {
+	trimmed := strings.TrimRight(l.text, " \t")
+	if trimmed == l.text || strings.HasPrefix(strings.TrimLeft(trimmed, " \t"), "#") {
+		return nil
+	}
+
+	// an escaped backslash does not continue the line
+	backslashes := len(trimmed) - len(strings.TrimRight(trimmed, `\`))
+	if backslashes%2 == 0 {
+		return nil
+	}
+
+	finding := parse.ContinuationWhitespaceError(file.Contents(), l.offset+len(trimmed), len(l.text)-len(trimmed))
+	finding.File = file.Name()
+
+	return finding
+}
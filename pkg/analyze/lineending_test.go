@@ -0,0 +1,70 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckLineEndings(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []finding
+	}{
+		{
+			name:     "POSITIVE - unix line endings",
+			contents: "SecRule ARGS \"@rx a\" \\\n    \"id:1,phase:2,deny\"\nSecMarker END\n",
+		},
+		{
+			name:     "POSITIVE - windows line endings",
+			contents: "SecRule ARGS \"@rx a\" \\\r\n    \"id:1,phase:2,deny\"\r\nSecMarker END\r\n",
+		},
+		{
+			name:     "POSITIVE - escaped backslash followed by whitespace",
+			contents: "SecRule ARGS \"@rx a\" \"id:1,phase:2,deny,msg:'a\\\\ \n'\"",
+		},
+		{
+			name:     "POSITIVE - comment ending with a backslash and whitespace",
+			contents: "# see C:\\ \nSecMarker END\n",
+		},
+		{
+			name:     "NEGATIVE - line ending differs from the other lines",
+			contents: "SecRule ARGS \"@rx a\" \\\r\n    \"id:1,phase:2,deny\"\nSecMarker END\r\n",
+			want: []finding{
+				{
+					Message: `line ends with "\n" while the other lines of the file end with "\r\n"`,
+					Lexeme:  `    "id:1,phase:2,deny"`,
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - empty line ending differs from the other lines",
+			contents: "SecMarker A\n\r\nSecMarker B\n",
+			want: []finding{
+				{
+					Message: `line ends with "\r\n" while the other lines of the file end with "\n"`,
+					Lexeme:  "\r\n",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - whitespace after a continuation within a quoted option",
+			contents: "SecRule ARGS \"@rx a\" \"id:1,\\ \t\n    phase:2,deny\"",
+			want: []finding{
+				{
+					Message: "whitespace after the line continuation backslash, the line is not continued",
+					Lexeme:  " \t",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkLineEndings, tt.contents)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package analyze
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// actions whose values are expanded by Coraza, setvar
+// macros are validated by checkSetvar
+var macroActions = []string{"msg", "logdata", "setenv", "initcol", "expirevar", "redirect"}
+
+// operators whose argument is expanded by Coraza, the others such
+// as @rx read "%{" literally
+var macroOperators = []string{"beginsWith", "contains", "containsWord", "endsWith", "eq", "ge", "gt", "le", "lt", "streq", "within"}
+
+// represents a TX variable read by a rule
+type txRead struct {
+	// rule reading the variable
+	rule *rule
+
+	// key of the variable within TX
+	key string
+
+	// offset and distance of the read within the rule's file
+	offset   int
+	distance int
+}
+
+// validates macro syntax wherever macros are expanded and
+// reports TX variables which are read but not set by a rule
+// loaded at or before the reading rule, phases are not taken
+// into account since a variable read in an earlier phase than
+// it is set is usually initialized with a default by CRS
+func checkMacros(rs *ruleset) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		reads    []txRead
+
+		// load position of the first rule setting each variable
+		set = map[string]int{}
+	)
+
+	for _, r := range rs.rules {
+		var macros []*parse.Macro
+
+		for _, text := range macroTexts(r) {
+			parsed, err := parse.ParseMacros(r.file.Contents(), text.text, text.offset)
+			if err != nil {
+				findings = appendLinterError(findings, r.file, err)
+
+				continue
+			}
+
+			macros = append(macros, parsed...)
+		}
+
+		for _, setvar := range r.actionsNamed(parse.ActionSetVar) {
+			parsed, err := parse.ParseSetvar(r.file.Contents(), setvar)
+			if err != nil {
+				continue
+			}
+
+			if strings.EqualFold(parsed.Collection, "TX") && !strings.Contains(parsed.Key, "%{") {
+				key := strings.ToLower(parsed.Key)
+				if _, ok := set[key]; !ok {
+					set[key] = rs.positions[r.directive]
+				}
+			}
+
+			// setvar values are validated by checkSetvar
+			valueMacros, _ := parse.ParseMacros(r.file.Contents(), parsed.Value, parsed.ValueOffset)
+			macros = append(macros, valueMacros...)
+		}
+
+		for _, macro := range macros {
+			if !knownVariable(macro.Name) {
+				findings = append(findings, newLinterError(
+					r.file,
+					ErrUnknownMacroVariable,
+					parse.ParseLevelWarning,
+					macro.Offset,
+					macro.Len(),
+					fmt.Sprintf("unknown variable %q in macro", macro.Name),
+				))
+			}
+
+			if strings.EqualFold(macro.Name, "TX") {
+				reads = append(reads, txRead{rule: r, key: macro.Key, offset: macro.Offset, distance: macro.Len()})
+			}
+		}
+
+		for _, variable := range r.variables {
+			if !strings.EqualFold(variable.Name, "TX") || variable.Count || variable.Exclude || variable.KeyIsRegex() {
+				continue
+			}
+
+			reads = append(reads, txRead{rule: r, key: variable.Key, offset: variable.Offset, distance: variable.Len()})
+		}
+	}
+
+	for _, read := range reads {
+		if read.key == "" || guardedRead(read) {
+			continue
+		}
+
+		// captures are set by the capture action
+		if _, err := strconv.Atoi(read.key); err == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("TX:%s is read but never set by a setvar action", read.key)
+
+		if position, ok := set[strings.ToLower(read.key)]; ok {
+			if position <= rs.positions[read.rule.directive] {
+				continue
+			}
+
+			message = fmt.Sprintf("TX:%s is read before any setvar action sets it", read.key)
+		}
+
+		findings = append(findings, newLinterError(
+			read.rule.file,
+			ErrUnsetVariable,
+			parse.ParseLevelWarning,
+			read.offset,
+			read.distance,
+			message,
+		))
+	}
+
+	return findings
+}
+
+// reports whether the chain of the reading rule checks that
+// the variable exists by counting it, ex. &TX:max_num_args
+func guardedRead(read txRead) bool {
+	chainStart := read.rule.chainStart()
+
+	for _, r := range append([]*rule{chainStart}, chainStart.chain...) {
+		for _, variable := range r.variables {
+			if variable.Count && strings.EqualFold(variable.Name, "TX") && strings.EqualFold(variable.Key, read.key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// represents text which may contain macros
+type macroText struct {
+	// the text itself
+	text string
+
+	// offset of the text within the entire file
+	offset int
+}
+
+// returns the operator argument and action values of the rule
+// which Coraza expands macros in, excluding setvar values
+func macroTexts(r *rule) []macroText {
+	var texts []macroText
+
+	if r.operator != nil && slices.ContainsFunc(macroOperators, func(name string) bool {
+		return strings.EqualFold(r.operator.Name, name)
+	}) {
+		texts = append(texts, macroText{text: r.operator.Argument, offset: r.operator.ArgumentOffset})
+	}
+
+	for _, action := range r.actions {
+		if slices.ContainsFunc(macroActions, func(name string) bool {
+			return strings.EqualFold(action.Name, name)
+		}) {
+			texts = append(texts, macroText{text: action.Value, offset: action.ValueOffset})
+		}
+	}
+
+	return texts
+}
+
+// reports whether Coraza supports the variable, case insensitive
+func knownVariable(name string) bool {
+	return slices.ContainsFunc(parse.VariableNames(), func(known string) bool {
+		return strings.EqualFold(name, known)
+	})
+}
@@ -0,0 +1,127 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckMacros(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - macros of variables set in load order",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:'tx.allowed_methods=GET HEAD'"`,
+				`SecRule REQUEST_METHOD "!@within %{tx.allowed_methods}" \` + "\n" +
+					`    "id:2,phase:1,block,msg:'Method %{MATCHED_VAR} is not allowed',logdata:'%{MATCHED_VAR_NAME}'"`,
+			},
+		},
+		{
+			name: "POSITIVE - reads guarded by an existence check",
+			contents: []string{
+				`SecRule &TX:max_num_args "@eq 1" "id:1,phase:2,block,chain"` + "\n" +
+					`SecRule &ARGS "@gt %{tx.max_num_args}"`,
+			},
+		},
+		{
+			name: "POSITIVE - captures and counted variables",
+			contents: []string{
+				`SecRule ARGS "@rx (a)" "id:1,phase:2,capture,block,chain"` + "\n" +
+					`SecRule TX:1 "@eq %{tx.0}"` + "\n" +
+					`SecRule &TX:undefined "@eq 0" "id:2,phase:1,pass"`,
+			},
+		},
+		{
+			name: "POSITIVE - regular expressions are not expanded",
+			contents: []string{
+				`SecRule ARGS "@rx %{[a-z]+" "id:1,phase:2,block"`,
+			},
+		},
+		{
+			name: "NEGATIVE - invalid macro in an operator argument",
+			contents: []string{
+				`SecRule ARGS "@streq %{tx.allowed" "id:1,phase:2,block"`,
+			},
+			want: []finding{
+				{
+					Message: "unterminated macro, expected '}'",
+					Lexeme:  "%{tx.allowed",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - invalid macro in msg",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:2,block,msg:'Matched %{MATCHED_VAR'"`,
+			},
+			want: []finding{
+				{
+					Message: "unterminated macro, expected '}'",
+					Lexeme:  "%{MATCHED_VAR",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - unknown variable in logdata",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:2,block,logdata:'%{MATCHED_VARS_NAME}'"`,
+			},
+			want: []finding{
+				{
+					Message: `unknown variable "MATCHED_VARS_NAME" in macro`,
+					Lexeme:  "%{MATCHED_VARS_NAME}",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - TX variable never set",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:'tx.blocking_paranoia_level=1'"`,
+				`SecRule TX:DETECTION_PARANOIA_LEVEL "@lt %{tx.blocking_paranoia_level}" "id:2,phase:1,pass"`,
+			},
+			want: []finding{
+				{
+					Message: "TX:DETECTION_PARANOIA_LEVEL is read but never set by a setvar action",
+					Lexeme:  "TX:DETECTION_PARANOIA_LEVEL",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - TX variable never set read by macro",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:2,block,setvar:'tx.score=+%{tx.critical_score}'"`,
+			},
+			want: []finding{
+				{
+					Message: "TX:critical_score is read but never set by a setvar action",
+					Lexeme:  "%{tx.critical_score}",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - TX variable set by a rule loaded after the read",
+			contents: []string{
+				`SecRule TX:blocking_paranoia_level "@ge 2" "id:1,phase:1,pass"`,
+				`SecAction "id:2,phase:1,pass,nolog,setvar:'tx.blocking_paranoia_level=1'"`,
+			},
+			want: []finding{
+				{
+					Message: "TX:blocking_paranoia_level is read before any setvar action sets it",
+					Lexeme:  "TX:blocking_paranoia_level",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkMacros, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package analyze
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// represents a SecMarker directive
+type marker struct {
+	// file the marker is declared in
+	file *parse.File
+
+	// directive declaring the marker
+	directive *parse.Directive
+
+	// name of the marker
+	name string
+
+	// position of the marker in load order
+	position int
+
+	// position of the previous marker in load order,
+	// -1 if no marker is declared before this marker
+	previous int
+}
+
+// verifies that every skipAfter action jumps forward to an
+// existing SecMarker and that every SecMarker is jumped to
+func checkMarkers(rs *ruleset) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		markers  = map[string][]*marker{}
+		ordered  []*marker
+		targeted = map[string]bool{}
+	)
+
+	for _, file := range rs.files {
+		for _, directive := range file.Directives {
+			if !strings.EqualFold(directive.Lexeme, parse.DirectiveSecMarker) || len(directive.Options) == 0 {
+				continue
+			}
+
+			ordered = append(ordered, &marker{
+				file:      file,
+				directive: directive,
+				name:      markerName(directive.Options[0].Content()),
+				position:  rs.positions[directive],
+			})
+		}
+	}
+
+	// files loaded by Include directives sit between the directives
+	// of the including file, so sections follow load order
+	slices.SortFunc(ordered, func(a, b *marker) int {
+		return a.position - b.position
+	})
+
+	previous := -1
+
+	for _, m := range ordered {
+		m.previous = previous
+		previous = m.position
+
+		markers[m.name] = append(markers[m.name], m)
+	}
+
+	for _, r := range rs.rules {
+		for _, skip := range r.actionsNamed("skipAfter") {
+			name := markerName(skip.Value)
+			targeted[name] = true
+
+			findings = append(findings, checkSkipAfter(rs, r, skip, markers[name])...)
+		}
+	}
+
+	for _, m := range ordered {
+		if targeted[m.name] {
+			continue
+		}
+
+		findings = append(findings, newLinterError(
+			m.file,
+			ErrUnusedMarker,
+			parse.ParseLevelWarning,
+			m.directive.Offset,
+			m.directive.Len(),
+			fmt.Sprintf("no skipAfter action jumps to marker %q", m.name),
+		))
+	}
+
+	return findings
+}
+
+// verifies a single skipAfter action of the given rule against
+// the markers declared with the name it jumps to
+func checkSkipAfter(rs *ruleset, r *rule, skip *parse.Action, candidates []*marker) []*parse.LinterError {
+	name := markerName(skip.Value)
+	position := rs.positions[r.directive]
+
+	var target *marker
+
+	for _, m := range candidates {
+		if m.position > position {
+			target = m
+
+			break
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		return []*parse.LinterError{
+			actionError(
+				r.file,
+				ErrUndeclaredMarker,
+				parse.ParseLevelError,
+				skip,
+				fmt.Sprintf("skipAfter jumps to marker %q which is never declared", name),
+			),
+		}
+	case target == nil:
+		return []*parse.LinterError{
+			actionError(
+				r.file,
+				ErrBackwardSkip,
+				parse.ParseLevelError,
+				skip,
+				fmt.Sprintf("skipAfter jumps backwards to marker %q, markers must be declared after the rule", name),
+			),
+		}
+	}
+
+	// a marker ends the section of rules declared since the previous
+	// marker, jumping into a section without rules of the rule's
+	// phase skips rules of unrelated sections
+	if position > target.previous {
+		return nil
+	}
+
+	phases := map[int]bool{}
+
+	for _, other := range rs.rules {
+		otherPosition := rs.positions[other.directive]
+		if otherPosition > target.previous && otherPosition < target.position {
+			phases[other.phase] = true
+		}
+	}
+
+	if len(phases) == 0 || phases[r.phase] {
+		return nil
+	}
+
+	return []*parse.LinterError{
+		actionError(
+			r.file,
+			ErrMarkerPhase,
+			parse.ParseLevelWarning,
+			skip,
+			fmt.Sprintf(
+				"skipAfter jumps to marker %q ending a section without phase %d rules",
+				name,
+				r.phase,
+			),
+		),
+	}
+}
+
+// returns the marker name without surrounding quotes
+func markerName(name string) string {
+	return strings.Trim(strings.TrimSpace(name), `'"`)
+}
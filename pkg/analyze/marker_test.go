@@ -0,0 +1,99 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - skipAfter to a later marker",
+			contents: []string{
+				`SecRule TX:level "@lt 1" "id:1,phase:1,pass,skipAfter:END-CHECKS"` + "\n" +
+					`SecRule ARGS "@rx foo" "id:2,phase:1,deny"` + "\n" +
+					`SecMarker "END-CHECKS"`,
+			},
+		},
+		{
+			name: "POSITIVE - skipAfter to a marker in a later file",
+			contents: []string{
+				`SecRule TX:level "@lt 1" "id:1,phase:2,pass,skipAfter:END-CHECKS"`,
+				`SecRule ARGS "@rx foo" "id:2,phase:2,deny"` + "\n" +
+					`SecMarker END-CHECKS`,
+			},
+		},
+		{
+			name: "POSITIVE - skipAfter to a lowercase marker directive",
+			contents: []string{
+				`SecRule TX:level "@lt 1" "id:1,phase:1,pass,skipAfter:LAST"` + "\n" +
+					`SecRule ARGS "@rx foo" "id:2,phase:1,deny"` + "\n" +
+					`secmarker LAST`,
+			},
+		},
+		{
+			name: "NEGATIVE - skipAfter to a marker never declared",
+			contents: []string{
+				`SecRule TX:level "@lt 1" "id:1,phase:1,pass,skipAfter:END-CHECK"` + "\n" +
+					`SecRule ARGS "@rx foo" "id:2,phase:1,deny"` + "\n" +
+					`SecMarker "END-CHECKS"`,
+			},
+			want: []finding{
+				{
+					Message: `skipAfter jumps to marker "END-CHECK" which is never declared`,
+					Lexeme:  "skipAfter:END-CHECK",
+				},
+				{
+					Message: `no skipAfter action jumps to marker "END-CHECKS"`,
+					Lexeme:  `SecMarker "END-CHECKS"`,
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - skipAfter jumps backwards",
+			contents: []string{
+				`SecMarker "BEGIN-CHECKS"`,
+				`SecRule TX:level "@lt 1" "id:1,phase:1,pass,skipAfter:BEGIN-CHECKS"`,
+			},
+			want: []finding{
+				{
+					Message: `skipAfter jumps backwards to marker "BEGIN-CHECKS", markers must be declared after the rule`,
+					Lexeme:  "skipAfter:BEGIN-CHECKS",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - skipAfter into a section of another phase",
+			contents: []string{
+				`SecRule TX:level "@lt 1" "id:1,phase:2,pass,skipAfter:END-RESPONSE"` + "\n" +
+					`SecRule ARGS "@rx foo" "id:2,phase:2,deny"` + "\n" +
+					`SecMarker "END-REQUEST"`,
+				`SecRule RESPONSE_BODY "@rx foo" "id:3,phase:4,deny"` + "\n" +
+					`SecMarker "END-RESPONSE"`,
+			},
+			want: []finding{
+				{
+					Message: `skipAfter jumps to marker "END-RESPONSE" ending a section without phase 2 rules`,
+					Lexeme:  "skipAfter:END-RESPONSE",
+				},
+				{
+					Message: `no skipAfter action jumps to marker "END-REQUEST"`,
+					Lexeme:  `SecMarker "END-REQUEST"`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkMarkers, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package analyze
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// validates the argument of an operator
+type operatorValidator func(r *rule, operator *parse.Operator) []*parse.LinterError
+
+// argument validators of operators, keyed by lowercase name
+var operatorValidators = map[string]operatorValidator{
+	"eq":                operatorInteger,
+	"ge":                operatorInteger,
+	"gt":                operatorInteger,
+	"le":                operatorInteger,
+	"lt":                operatorInteger,
+	"ipmatch":           operatorIPs,
+	"validatebyterange": operatorByteRanges,
+}
+
+// matches an argument consisting of a single macro
+var patternSingleMacro = regexp.MustCompile(`^%\{[^{}%\s]+\}$`)
+
+// reports unknown operators and validates the arguments
+// of operators expecting numbers, IPs or byte ranges
+func checkOperators(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		if r.operator == nil {
+			continue
+		}
+
+		if !slices.ContainsFunc(parse.OperatorNames(), func(name string) bool {
+			return strings.EqualFold(r.operator.Name, name)
+		}) {
+			findings = append(findings, newLinterError(
+				r.file,
+				ErrUnknownOperator,
+				parse.ParseLevelError,
+				r.operator.Offset,
+				len(r.operator.Name)+1,
+				fmt.Sprintf("unknown operator %q", "@"+r.operator.Name),
+			))
+
+			continue
+		}
+
+		if validate, ok := operatorValidators[strings.ToLower(r.operator.Name)]; ok {
+			findings = append(findings, validate(r, r.operator)...)
+		}
+	}
+
+	return findings
+}
+
+// validates that the argument is an integer or a single macro
+func operatorInteger(r *rule, operator *parse.Operator) []*parse.LinterError {
+	if _, err := strconv.Atoi(operator.Argument); err == nil || patternSingleMacro.MatchString(operator.Argument) {
+		return nil
+	}
+
+	return []*parse.LinterError{
+		operatorArgumentError(r, operator, parse.Word{Text: operator.Argument, Offset: operator.ArgumentOffset}, fmt.Sprintf(
+			"invalid argument %q for @%s, expected an integer or a macro",
+			operator.Argument,
+			operator.Name,
+		)),
+	}
+}
+
+// validates that the argument is a comma separated list
+// of IPv4 or IPv6 addresses and CIDRs
+func operatorIPs(r *rule, operator *parse.Operator) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, w := range splitArgument(operator, ',') {
+		if _, ok := parseIPEntry(w.Text); ok {
+			continue
+		}
+
+		findings = append(findings, operatorArgumentError(r, operator, w, fmt.Sprintf(
+			"invalid IP address or CIDR %q for @%s",
+			w.Text,
+			operator.Name,
+		)))
+	}
+
+	return findings
+}
+
+// validates that the argument is a comma separated list
+// of bytes and byte ranges within 0-255
+func operatorByteRanges(r *rule, operator *parse.Operator) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, w := range splitArgument(operator, ',') {
+		if validByteRange(w.Text) {
+			continue
+		}
+
+		findings = append(findings, operatorArgumentError(r, operator, w, fmt.Sprintf(
+			"invalid byte or byte range %q for @%s, expected values within 0-255 such as 32 or 48-57",
+			w.Text,
+			operator.Name,
+		)))
+	}
+
+	return findings
+}
+
+// reports whether the value is a byte or an ascending byte range
+func validByteRange(value string) bool {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+
+	start, err := strconv.Atoi(startValue)
+	if err != nil || start < 0 || start > 255 {
+		return false
+	}
+
+	if !isRange {
+		return true
+	}
+
+	end, err := strconv.Atoi(endValue)
+
+	return err == nil && start <= end && end <= 255
+}
+
+// splits the operator argument at the separator into
+// words without surrounding whitespace
+func splitArgument(operator *parse.Operator, separator byte) []parse.Word {
+	var words []parse.Word
+
+	for start := 0; start <= len(operator.Argument); {
+		end := strings.IndexByte(operator.Argument[start:], separator)
+		if end == -1 {
+			end = len(operator.Argument) - start
+		}
+
+		raw := operator.Argument[start : start+end]
+		trimmed := strings.TrimSpace(raw)
+
+		words = append(words, parse.Word{
+			Text:   trimmed,
+			Offset: operator.ArgumentOffset + start + strings.Index(raw, trimmed),
+		})
+
+		start += end + 1
+	}
+
+	return words
+}
+
+// creates an error pointing at the given word of the operator argument
+func operatorArgumentError(r *rule, operator *parse.Operator, w parse.Word, message string) *parse.LinterError {
+	if w.Text == "" {
+		return newLinterError(r.file, ErrInvalidOperatorArgument, parse.ParseLevelError, operator.Offset, len(operator.Name)+1, message)
+	}
+
+	return newLinterError(r.file, ErrInvalidOperatorArgument, parse.ParseLevelError, w.Offset, len(w.Text), message)
+}
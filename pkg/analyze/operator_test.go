@@ -0,0 +1,86 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - valid operator arguments",
+			contents: `SecRule TX:score "@ge %{tx.threshold}" "id:1,phase:2,deny"` + "\n" +
+				`SecRule TX:score "!@lt 5" "id:2,phase:2,deny"` + "\n" +
+				`SecRule REMOTE_ADDR "@ipMatch 127.0.0.1,::1, 10.0.0.0/8" "id:3,phase:1,deny"` + "\n" +
+				`SecRule ARGS "!@validateByteRange 20, 45-47, 48-57" "id:4,phase:2,deny"` + "\n" +
+				`SecRule ARGS "^foo$" "id:5,phase:2,deny"`,
+		},
+		{
+			name:     "NEGATIVE - unknown operator",
+			contents: `SecRule ARGS "@regex foo" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `unknown operator "@regex"`,
+					Lexeme:  "@regex",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - numeric operator with invalid integer",
+			contents: `SecRule TX:score "@lt 1O" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `invalid argument "1O" for @lt, expected an integer or a macro`,
+					Lexeme:  "1O",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - numeric operator without argument",
+			contents: `SecRule TX:score "@eq" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `invalid argument "" for @eq, expected an integer or a macro`,
+					Lexeme:  "@eq",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - invalid CIDR",
+			contents: `SecRule REMOTE_ADDR "@ipMatch 127.0.0.1,10.0.0.0/33" "id:1,phase:1,deny"`,
+			want: []finding{
+				{
+					Message: `invalid IP address or CIDR "10.0.0.0/33" for @ipMatch`,
+					Lexeme:  "10.0.0.0/33",
+				},
+			},
+		},
+		{
+			name:     "NEGATIVE - byte range out of bounds",
+			contents: `SecRule ARGS "@validateByteRange 32-126,128-256,50-40" "id:1,phase:2,deny"`,
+			want: []finding{
+				{
+					Message: `invalid byte or byte range "128-256" for @validateByteRange, expected values within 0-255 such as 32 or 48-57`,
+					Lexeme:  "128-256",
+				},
+				{
+					Message: `invalid byte or byte range "50-40" for @validateByteRange, expected values within 0-255 such as 32 or 48-57`,
+					Lexeme:  "50-40",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkOperators, tt.contents)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// first phase in which Coraza populates each variable.
+// Variables missing from the table are either available
+// in every phase or not known to the linter.
+var variablePhases = map[string]int{
+	// populated while processing the request body
+	"ARGS_POST":                        2,
+	"ARGS_POST_NAMES":                  2,
+	"FILES":                            2,
+	"FILES_COMBINED_SIZE":              2,
+	"FILES_NAMES":                      2,
+	"FILES_SIZES":                      2,
+	"FILES_TMPNAMES":                   2,
+	"FILES_TMP_CONTENT":                2,
+	"FULL_REQUEST":                     2,
+	"FULL_REQUEST_LENGTH":              2,
+	"INBOUND_DATA_ERROR":               2,
+	"JSON":                             2,
+	"MULTIPART_BOUNDARY_QUOTED":        2,
+	"MULTIPART_BOUNDARY_WHITESPACE":    2,
+	"MULTIPART_CRLF_LF_LINES":          2,
+	"MULTIPART_DATA_AFTER":             2,
+	"MULTIPART_DATA_BEFORE":            2,
+	"MULTIPART_FILENAME":               2,
+	"MULTIPART_FILE_LIMIT_EXCEEDED":    2,
+	"MULTIPART_HEADER_FOLDING":         2,
+	"MULTIPART_INVALID_HEADER_FOLDING": 2,
+	"MULTIPART_INVALID_PART":           2,
+	"MULTIPART_INVALID_QUOTING":        2,
+	"MULTIPART_LF_LINE":                2,
+	"MULTIPART_MISSING_SEMICOLON":      2,
+	"MULTIPART_NAME":                   2,
+	"MULTIPART_PART_HEADERS":           2,
+	"MULTIPART_STRICT_ERROR":           2,
+	"MULTIPART_UNMATCHED_BOUNDARY":     2,
+	"REQBODY_ERROR":                    2,
+	"REQBODY_ERROR_MSG":                2,
+	"REQBODY_PROCESSOR_ERROR":          2,
+	"REQBODY_PROCESSOR_ERROR_MSG":      2,
+	"REQUEST_BODY":                     2,
+	"REQUEST_BODY_LENGTH":              2,
+	"XML":                              2,
+
+	// populated while processing the response headers
+	"RESPONSE_CONTENT_LENGTH": 3,
+	"RESPONSE_CONTENT_TYPE":   3,
+	"RESPONSE_HEADERS":        3,
+	"RESPONSE_HEADERS_NAMES":  3,
+	"RESPONSE_PROTOCOL":       3,
+	"RESPONSE_STATUS":         3,
+	"STATUS_LINE":             3,
+
+	// populated while processing the response body
+	"OUTBOUND_DATA_ERROR": 4,
+	"RESPONSE_ARGS":       4,
+	"RESPONSE_BODY":       4,
+	"RESPONSE_XML":        4,
+}
+
+// reports rule targets which Coraza cannot populate
+// in the phase the rule runs in
+func checkVariablePhases(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		for _, variable := range r.variables {
+			if variable.Exclude {
+				continue
+			}
+
+			available, ok := variablePhases[strings.ToUpper(variable.Name)]
+			if !ok || available <= r.phase {
+				continue
+			}
+
+			origin := ""
+			switch {
+			case r.phaseInherited:
+				origin = " inherited from SecDefaultAction"
+			case len(r.chainStart().actionsNamed("phase")) == 0:
+				origin = " by default"
+			}
+
+			findings = append(findings, newLinterError(
+				r.file,
+				ErrVariablePhase,
+				parse.ParseLevelWarning,
+				variable.Offset,
+				variable.Len(),
+				fmt.Sprintf(
+					"variable %s is not populated before phase %d, but the rule runs in phase %d%s",
+					variable.Name,
+					available,
+					r.phase,
+					origin,
+				),
+			))
+		}
+	}
+
+	return findings
+}
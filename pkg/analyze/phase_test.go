@@ -0,0 +1,86 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckVariablePhases(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - request headers in phase 1",
+			contents: []string{
+				`SecRule REQUEST_HEADERS:Host "@rx foo" "id:1,phase:1,pass"`,
+			},
+		},
+		{
+			name: "POSITIVE - excluded response variable",
+			contents: []string{
+				`SecRule ARGS|!RESPONSE_HEADERS:foo "@rx foo" "id:1,phase:1,pass"`,
+			},
+		},
+		{
+			name: "NEGATIVE - response body in phase 1",
+			contents: []string{
+				`SecRule RESPONSE_BODY "@rx foo" "id:1,phase:1,pass"`,
+			},
+			want: []finding{
+				{
+					Message: "variable RESPONSE_BODY is not populated before phase 4, but the rule runs in phase 1",
+					Lexeme:  "RESPONSE_BODY",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - request body in chained rule of phase 1",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:1,pass,chain"` + "\n" +
+					`SecRule &REQUEST_BODY "@eq 0"`,
+			},
+			want: []finding{
+				{
+					Message: "variable REQUEST_BODY is not populated before phase 2, but the rule runs in phase 1",
+					Lexeme:  "&REQUEST_BODY",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - response headers in default phase",
+			contents: []string{
+				`SecRule RESPONSE_HEADERS "@rx foo" "id:1,pass"`,
+			},
+			want: []finding{
+				{
+					Message: "variable RESPONSE_HEADERS is not populated before phase 3, but the rule runs in phase 2 by default",
+					Lexeme:  "RESPONSE_HEADERS",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - request body in phase inherited from SecDefaultAction",
+			contents: []string{
+				`SecDefaultAction "phase:1,log,pass"`,
+				`SecRule REQUEST_BODY "@rx foo" "id:1,pass"`,
+			},
+			want: []finding{
+				{
+					Message: "variable REQUEST_BODY is not populated before phase 2, but the rule runs in phase 1 inherited from SecDefaultAction",
+					Lexeme:  "REQUEST_BODY",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkVariablePhases, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
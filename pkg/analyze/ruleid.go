@@ -0,0 +1,63 @@
+package analyze
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// reports rules declaring the ID of a rule loaded before them,
+// which Coraza refuses to load, across all analyzed files
+func checkRuleIDs(rs *ruleset) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		declared = map[int]*rule{}
+	)
+
+	for _, r := range rs.rules {
+		// chained rules share the ID of the rule starting the chain
+		if r.parent != nil {
+			continue
+		}
+
+		action := r.lastAction("id")
+		if action == nil {
+			continue
+		}
+
+		id, err := strconv.Atoi(action.Value)
+		if err != nil {
+			continue
+		}
+
+		first, ok := declared[id]
+		if !ok {
+			declared[id] = r
+
+			continue
+		}
+
+		findings = append(findings, actionError(
+			r.file,
+			ErrDuplicateRuleID,
+			parse.ParseLevelError,
+			action,
+			fmt.Sprintf("duplicate rule ID %d, first declared %s", id, declaredAt(r.file, first)),
+		))
+	}
+
+	return findings
+}
+
+// describes where the rule is declared, relative to the given file
+func declaredAt(file *parse.File, r *rule) string {
+	line := bytes.Count(r.file.Contents()[:r.directive.Offset], []byte("\n")) + 1
+
+	if r.file == file || r.file.Name() == "" {
+		return fmt.Sprintf("on line %d", line)
+	}
+
+	return fmt.Sprintf("in %s on line %d", r.file.Name(), line)
+}
@@ -0,0 +1,89 @@
+package analyze
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/go-test/deep"
+)
+
+func TestCheckRuleIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - unique IDs",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,phase:2,deny,chain"` + "\n" +
+					`SecRule ARGS "@rx bar" "t:none"`,
+				`SecAction "id:2,phase:1,pass,nolog"`,
+			},
+		},
+		{
+			name: "NEGATIVE - ID declared twice in a file",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog"` + "\n" +
+					`SecRule ARGS "@rx foo" "id:1,phase:2,deny"`,
+			},
+			want: []finding{
+				{
+					Message: "duplicate rule ID 1, first declared on line 1",
+					Lexeme:  "id:1",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - ID declared in another file",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog"`,
+				`SecRule ARGS "@rx foo" "id:1,phase:2,deny"`,
+			},
+			want: []finding{
+				{
+					Message: "duplicate rule ID 1, first declared on line 1",
+					Lexeme:  "id:1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkRuleIDs, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCheckRuleIDsNamesFirstFile(t *testing.T) {
+	var files []*parse.File
+
+	for _, named := range [][2]string{
+		{"setup.conf", "SecMarker START\n" + `SecAction "id:1,phase:1,pass,nolog"`},
+		{"rules.conf", `SecRule ARGS "@rx foo" "id:1,phase:2,deny"`},
+	} {
+		file, err := parse.ParseReader(named[0], strings.NewReader(named[1]))
+		if err != nil {
+			t.Fatalf("could not parse test contents: %v", err)
+		}
+
+		files = append(files, file)
+	}
+
+	var got []string
+	for _, finding := range parse.LinterErrors(Analyze(Options{}, files...)) {
+		if finding.Is(ErrDuplicateRuleID) {
+			got = append(got, finding.File+": "+finding.Message)
+		}
+	}
+
+	want := []string{"rules.conf: duplicate rule ID 1, first declared in setup.conf on line 2"}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,347 @@
+package analyze
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// phase used by Coraza when a rule does not declare one
+const defaultPhase = 2
+
+// represents a rule declared by SecRule or SecAction
+type rule struct {
+	// file the rule is declared in
+	file *parse.File
+
+	// directive declaring the rule
+	directive *parse.Directive
+
+	// variables targeted by the rule, empty for SecAction
+	variables []*parse.Variable
+
+	// operator of the rule, nil for SecAction
+	operator *parse.Operator
+
+	// actions listed by the rule itself
+	actions []*parse.Action
+
+	// actions inherited from the SecDefaultAction of the rule's
+	// phase, always empty for chained rules
+	defaults []*parse.Action
+
+	// phase the rule runs in
+	phase int
+
+	// reports whether the phase is inherited from SecDefaultAction
+	phaseInherited bool
+
+	// rule starting the chain this rule belongs to,
+	// nil if the rule starts a chain or is not chained
+	parent *rule
+
+	// rules chained to this rule, in declaration order
+	chain []*rule
+}
+
+// returns the actions of the rule with the given name,
+// in declaration order
+func (r *rule) actionsNamed(name string) []*parse.Action {
+	return actionsNamed(r.actions, name)
+}
+
+// returns the rule starting the chain of the given rule,
+// or the rule itself if it is not chained
+func (r *rule) chainStart() *rule {
+	if r.parent != nil {
+		return r.parent
+	}
+
+	return r
+}
+
+// returns the last action of the rule with the given name,
+// nil if the rule does not list it
+func (r *rule) lastAction(name string) *parse.Action {
+	named := r.actionsNamed(name)
+	if len(named) == 0 {
+		return nil
+	}
+
+	return named[len(named)-1]
+}
+
+// returns the value of the id action of the rule,
+// empty if the rule does not declare an id
+func (r *rule) id() string {
+	if id := r.lastAction("id"); id != nil {
+		return id.Value
+	}
+
+	return ""
+}
+
+// returns the values of the tag actions of the rule
+func (r *rule) tags() []string {
+	tags := r.actionsNamed("tag")
+
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, tag.Value)
+	}
+
+	return values
+}
+
+// represents all rules of the analyzed files in load order
+type ruleset struct {
+	// analyzed files in load order
+	files []*parse.File
+
+	// position of every directive in load order
+	positions map[*parse.Directive]int
+
+	// all rules in load order, including chained rules
+	rules []*rule
+
+	// options the ruleset is analyzed with
+	options Options
+}
+
+// builds the ruleset of the given files, along with errors
+// found while reading the action lists of the rules. Files
+// loaded by an Include directive take their place in load
+// order at the directive, as Coraza loads them.
+func newRuleset(files []*parse.File) (*ruleset, []*parse.LinterError) {
+	var (
+		rs       = &ruleset{files: files, positions: map[*parse.Directive]int{}}
+		errs     []*parse.LinterError
+		defaults = map[int][]*parse.Action{}
+
+		// phase of the last SecDefaultAction, inherited
+		// by rules which do not declare a phase
+		inheritedPhase = 0
+	)
+
+	var (
+		analyzed = map[*parse.File]bool{}
+		loaded   = map[*parse.File]bool{}
+		load     func(file *parse.File)
+	)
+
+	for _, file := range files {
+		analyzed[file] = true
+	}
+
+	load = func(file *parse.File) {
+		if loaded[file] {
+			return
+		}
+
+		loaded[file] = true
+
+		// chains never continue into the next file
+		var chainStart, chainEnd *rule
+
+		for _, directive := range file.Directives {
+			rs.positions[directive] = len(rs.positions)
+
+			// included files are loaded at the Include directive,
+			// before the directives following it
+			for _, included := range file.Included(directive) {
+				if analyzed[included] {
+					load(included)
+				}
+			}
+
+			if !declaresActions(directive) {
+				continue
+			}
+
+			var actions []*parse.Action
+
+			if option := actionsOption(directive); option != nil {
+				parsed, err := parse.ParseActions(file.Contents(), option)
+				if err != nil {
+					errs = appendLinterError(errs, file, err)
+				}
+
+				actions = parsed
+			}
+
+			if strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction) {
+				inheritedPhase = phaseOf(actions, defaultPhase)
+				defaults[inheritedPhase] = actions
+
+				continue
+			}
+
+			r := &rule{
+				file:      file,
+				directive: directive,
+				actions:   actions,
+			}
+
+			if strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) > 0 {
+				variables, err := parse.ParseVariables(file.Contents(), directive.Options[0])
+				if err != nil {
+					errs = appendLinterError(errs, file, err)
+				}
+
+				r.variables = variables
+			}
+
+			if strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) > 1 {
+				operator, err := parse.ParseOperator(file.Contents(), directive.Options[1])
+				if err != nil {
+					errs = appendLinterError(errs, file, err)
+				}
+
+				r.operator = operator
+			}
+
+			switch {
+			case chainEnd != nil:
+				r.parent = chainStart
+				r.phase = chainStart.phase
+				r.phaseInherited = chainStart.phaseInherited
+				chainStart.chain = append(chainStart.chain, r)
+			case len(actionsNamed(actions, "phase")) == 0 && inheritedPhase != 0:
+				r.phase = inheritedPhase
+				r.phaseInherited = true
+			default:
+				r.phase = phaseOf(actions, defaultPhase)
+			}
+
+			if r.parent == nil {
+				r.defaults = defaults[r.phase]
+				chainStart = r
+			}
+
+			chainEnd = nil
+			if len(actionsNamed(actions, "chain")) > 0 {
+				chainEnd = r
+			}
+
+			rs.rules = append(rs.rules, r)
+		}
+	}
+
+	for _, file := range files {
+		load(file)
+	}
+
+	return rs, errs
+}
+
+// returns a ruleset for every file holding only the rules of that
+// file, sharing the positions and options of the whole ruleset
+func (rs *ruleset) fileRulesets() []*ruleset {
+	var (
+		fileRulesets = make([]*ruleset, 0, len(rs.files))
+		byFile       = make(map[*parse.File]*ruleset, len(rs.files))
+	)
+
+	for _, file := range rs.files {
+		fileRuleset := &ruleset{
+			files:     []*parse.File{file},
+			positions: rs.positions,
+			options:   rs.options,
+		}
+
+		byFile[file] = fileRuleset
+		fileRulesets = append(fileRulesets, fileRuleset)
+	}
+
+	for _, r := range rs.rules {
+		byFile[r.file].rules = append(byFile[r.file].rules, r)
+	}
+
+	return fileRulesets
+}
+
+// appends the linter error found in the given file to the errors
+func appendLinterError(errs []*parse.LinterError, file *parse.File, err error) []*parse.LinterError {
+	var linterError *parse.LinterError
+	if !errors.As(err, &linterError) {
+		return errs
+	}
+
+	linterError.File = file.Name()
+
+	return append(errs, linterError)
+}
+
+// reports whether the directive declares a rule or default actions
+func declaresActions(directive *parse.Directive) bool {
+	return strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) ||
+		strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction) ||
+		strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction)
+}
+
+// returns the option holding the action list of the directive,
+// nil if the directive does not hold actions
+func actionsOption(directive *parse.Directive) *parse.Option {
+	switch {
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule):
+		if len(directive.Options) >= 3 {
+			return directive.Options[2]
+		}
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction),
+		strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction):
+		if len(directive.Options) >= 1 {
+			return directive.Options[0]
+		}
+	}
+
+	return nil
+}
+
+// returns the actions with the given name, in declaration order.
+// Action names are case insensitive.
+func actionsNamed(actions []*parse.Action, name string) []*parse.Action {
+	var named []*parse.Action
+
+	for _, action := range actions {
+		if strings.EqualFold(action.Name, name) {
+			named = append(named, action)
+		}
+	}
+
+	return named
+}
+
+// returns the phase declared by the last phase action,
+// or the fallback if there is no valid phase action
+func phaseOf(actions []*parse.Action, fallback int) int {
+	phases := actionsNamed(actions, "phase")
+	if len(phases) == 0 {
+		return fallback
+	}
+
+	phase, ok := parsePhase(phases[len(phases)-1].Value)
+	if !ok {
+		return fallback
+	}
+
+	return phase
+}
+
+// parses the value of the phase action
+func parsePhase(value string) (int, bool) {
+	switch strings.ToLower(value) {
+	case "1":
+		return 1, true
+	case "2", "request":
+		return 2, true
+	case "3":
+		return 3, true
+	case "4", "response":
+		return 4, true
+	case "5", "logging":
+		return 5, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package analyze
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// collections which setvar may write to
+var writableCollections = []string{"TX", "IP", "SESSION", "GLOBAL", "USER", "RESOURCE"}
+
+// validates the expression of every setvar action
+func checkSetvar(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		for i, action := range r.actions {
+			if !strings.EqualFold(action.Name, parse.ActionSetVar) {
+				continue
+			}
+
+			if i+1 < len(r.actions) && !action.Quoted() && splitByComma(r.actions[i+1]) {
+				findings = append(findings, newLinterError(
+					r.file,
+					ErrUnquotedSetvarValue,
+					parse.ParseLevelWarning,
+					action.Offset,
+					r.actions[i+1].Offset+r.actions[i+1].Len()-action.Offset,
+					"unquoted setvar value is split at the comma, enclose the value in single quotes",
+				))
+			}
+
+			setvar, err := parse.ParseSetvar(r.file.Contents(), action)
+			if err != nil {
+				findings = appendLinterError(findings, r.file, err)
+
+				continue
+			}
+
+			findings = append(findings, checkSetvarExpression(r, setvar)...)
+		}
+	}
+
+	return findings
+}
+
+// validates the collection, operation and value of a parsed setvar
+func checkSetvarExpression(r *rule, setvar *parse.Setvar) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	if !slices.Contains(writableCollections, strings.ToUpper(setvar.Collection)) {
+		findings = append(findings, newLinterError(
+			r.file,
+			ErrReadOnlyCollection,
+			parse.ParseLevelError,
+			setvar.Offset,
+			len(setvar.Collection),
+			fmt.Sprintf(
+				"collection %s is not writable by setvar, expected one of %s",
+				setvar.Collection,
+				strings.Join(writableCollections, ", "),
+			),
+		))
+	}
+
+	macros, err := parse.ParseMacros(r.file.Contents(), setvar.Value, setvar.ValueOffset)
+	if err != nil {
+		return appendLinterError(findings, r.file, err)
+	}
+
+	arithmetic := setvar.Operation == parse.SetvarOperationIncrement ||
+		setvar.Operation == parse.SetvarOperationDecrement
+
+	if _, err := strconv.Atoi(setvar.Value); arithmetic && err != nil && !isSingleMacro(setvar.Value, macros) {
+		findings = append(findings, newLinterError(
+			r.file,
+			ErrInvalidSetvarValue,
+			parse.ParseLevelError,
+			setvar.ValueOffset,
+			max(len(setvar.Value), 1),
+			fmt.Sprintf("setvar %s expects an integer or a macro, got %q", setvar.Operation, setvar.Value),
+		))
+	}
+
+	return findings
+}
+
+// reports whether the action looks like the remainder of a
+// setvar value which the action lexer split at a comma
+func splitByComma(action *parse.Action) bool {
+	for _, name := range parse.ActionNames() {
+		if strings.EqualFold(action.Name, name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// reports whether the value consists of exactly one macro
+func isSingleMacro(value string, macros []*parse.Macro) bool {
+	return len(macros) == 1 && macros[0].Lexeme == value
+}
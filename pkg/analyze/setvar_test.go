@@ -0,0 +1,82 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckSetvar(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - anomaly scoring",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,` +
+					`setvar:'tx.inbound_anomaly_score_pl1=+%{tx.critical_anomaly_score}',` +
+					`setvar:ip.hits=+1,` +
+					`setvar:!tx.allowed_methods,` +
+					`setvar:'tx.allowed_methods=GET,HEAD'"`,
+			},
+		},
+		{
+			name: "NEGATIVE - collection is not writable",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:'args.score=1'"`,
+			},
+			want: []finding{
+				{
+					Message: "collection args is not writable by setvar, expected one of TX, IP, SESSION, GLOBAL, USER, RESOURCE",
+					Lexeme:  "args",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - invalid macro in value",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:'tx.score=+%{tx.critical_anomaly_score'"`,
+			},
+			want: []finding{
+				{
+					Message: "unterminated macro, expected '}'",
+					Lexeme:  "%{tx.critical_anomaly_score",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - increment by text",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:'tx.score=+five'"`,
+			},
+			want: []finding{
+				{
+					Message: `setvar =+ expects an integer or a macro, got "five"`,
+					Lexeme:  "five",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - unquoted value with comma",
+			contents: []string{
+				`SecAction "id:1,phase:1,pass,nolog,setvar:tx.allowed_methods=GET,HEAD"`,
+			},
+			want: []finding{
+				{
+					Message: "unquoted setvar value is split at the comma, enclose the value in single quotes",
+					Lexeme:  "setvar:tx.allowed_methods=GET,HEAD",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkSetvar, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package analyze
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// transformations which produce the same output
+// when applied twice in a row
+var idempotentTransformations = map[string]bool{
+	"cmdline":            true,
+	"compresswhitespace": true,
+	"lowercase":          true,
+	"none":               true,
+	"normalizepath":      true,
+	"normalizepathwin":   true,
+	"removenulls":        true,
+	"removewhitespace":   true,
+	"replacenulls":       true,
+	"trim":               true,
+	"trimleft":           true,
+	"trimright":          true,
+	"uppercase":          true,
+}
+
+// transformations whose effect is discarded by the
+// transformation immediately following them
+var overriddenTransformations = map[string][]string{
+	"lowercase": {"uppercase"},
+	"uppercase": {"lowercase"},
+	"trimleft":  {"trim"},
+	"trimright": {"trim"},
+}
+
+// validates the transformation pipeline of every rule
+func checkTransformations(rs *ruleset) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, r := range rs.rules {
+		transformations := r.actionsNamed("t")
+
+		for _, t := range transformations {
+			if canonicalTransformation(t.Value) == "" {
+				findings = append(findings, newLinterError(
+					r.file,
+					ErrUnknownTransformation,
+					parse.ParseLevelError,
+					t.ValueOffset,
+					len(t.Value),
+					fmt.Sprintf("unknown transformation %q", t.Value),
+				))
+			}
+		}
+
+		findings = append(findings, checkTransformationReset(r, transformations)...)
+		findings = append(findings, checkRedundantTransformations(r, transformations)...)
+	}
+
+	return findings
+}
+
+// reports t:none which is not the first transformation, and
+// transformations appended to those inherited from SecDefaultAction
+func checkTransformationReset(r *rule, transformations []*parse.Action) []*parse.LinterError {
+	if len(transformations) == 0 {
+		return nil
+	}
+
+	var findings []*parse.LinterError
+
+	var (
+		hasNone = false
+		// reports whether a transformation other than t:none
+		// is listed since the start or the last t:none
+		discarded = false
+	)
+
+	for _, t := range transformations {
+		if canonicalTransformation(t.Value) != "none" {
+			discarded = true
+
+			continue
+		}
+
+		hasNone = true
+
+		// a repeated t:none is reported as a duplicate instead
+		if discarded {
+			findings = append(findings, actionError(
+				r.file,
+				ErrMisplacedReset,
+				parse.ParseLevelWarning,
+				t,
+				"t:none discards the transformations listed before it, list t:none first",
+			))
+		}
+
+		discarded = false
+	}
+
+	if hasNone {
+		return findings
+	}
+
+	for _, inherited := range actionsNamed(r.defaults, "t") {
+		if canonicalTransformation(inherited.Value) == "none" {
+			continue
+		}
+
+		finding := actionError(
+			r.file,
+			ErrInheritedTransformations,
+			parse.ParseLevelWarning,
+			transformations[0],
+			fmt.Sprintf(
+				"transformations are appended to t:%s inherited from SecDefaultAction, start the list with t:none",
+				inherited.Value,
+			),
+		)
+		finding.Fix = &parse.SuggestedFix{
+			Message: "insert t:none before the first transformation",
+			Edits:   []parse.TextEdit{{Offset: transformations[0].Offset, Replacement: "t:none,"}},
+		}
+
+		findings = append(findings, finding)
+
+		break
+	}
+
+	return findings
+}
+
+// reports transformations which provably have no effect
+func checkRedundantTransformations(r *rule, transformations []*parse.Action) []*parse.LinterError {
+	var (
+		findings []*parse.LinterError
+		// idempotent transformations applied since the last t:none
+		// whose effect no later transformation has undone
+		applied = map[string]bool{}
+	)
+
+	for i, t := range transformations {
+		current := canonicalTransformation(t.Value)
+
+		if applied[current] && idempotentTransformations[current] {
+			finding := actionError(
+				r.file,
+				ErrDuplicateTransformation,
+				parse.ParseLevelInfo,
+				t,
+				fmt.Sprintf(
+					"duplicate transformation t:%s has no effect",
+					t.Value,
+				),
+			)
+			finding.Fix = removeActionFix(r.actions, t, "remove the duplicate transformation")
+
+			findings = append(findings, finding)
+
+			continue
+		}
+
+		if i > 0 {
+			findings = append(findings, checkOverriddenTransformation(r, transformations[i-1], t)...)
+		}
+
+		applyTransformation(applied, current)
+	}
+
+	return findings
+}
+
+// reports the previous transformation when the current
+// one discards its effect, such as t:lowercase,t:uppercase
+func checkOverriddenTransformation(r *rule, previous, current *parse.Action) []*parse.LinterError {
+	var findings []*parse.LinterError
+
+	for _, overriding := range overriddenTransformations[canonicalTransformation(previous.Value)] {
+		if canonicalTransformation(current.Value) != overriding {
+			continue
+		}
+
+		finding := actionError(
+			r.file,
+			ErrOverriddenTransformation,
+			parse.ParseLevelWarning,
+			previous,
+			fmt.Sprintf(
+				"transformation t:%s has no effect, it is overridden by t:%s",
+				previous.Value,
+				current.Value,
+			),
+		)
+		finding.Fix = removeActionFix(r.actions, previous, "remove the overridden transformation")
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// records the transformation as applied, forgetting the ones whose
+// effect it may undo: t:none resets the pipeline, decoders and
+// t:replaceNulls can bring back what was removed, and overriding
+// transformations replace the ones they override
+func applyTransformation(applied map[string]bool, transformation string) {
+	if transformation == "none" || transformation == "replacenulls" || !idempotentTransformations[transformation] {
+		clear(applied)
+	}
+
+	// t:none is only a duplicate right after another t:none
+	delete(applied, "none")
+
+	for name := range applied {
+		if slices.Contains(overriddenTransformations[name], transformation) {
+			delete(applied, name)
+		}
+	}
+
+	if idempotentTransformations[transformation] {
+		applied[transformation] = true
+	}
+}
+
+// returns the lowercase name Coraza resolves the given
+// transformation to, empty if Coraza does not support it
+func canonicalTransformation(name string) string {
+	name = strings.ToLower(name)
+
+	switch name {
+	case "normalisepath":
+		return "normalizepath"
+	case "normalisepathwin":
+		return "normalizepathwin"
+	}
+
+	for _, supported := range parse.TransformationNames() {
+		if strings.ToLower(supported) == name {
+			return name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,176 @@
+package analyze
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCheckTransformations(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents []string
+		want     []finding
+	}{
+		{
+			name: "POSITIVE - reset pipeline",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:urlDecodeUni,t:htmlEntityDecode,t:lowercase"`,
+			},
+		},
+		{
+			name: "POSITIVE - transformation names are case insensitive",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:LowerCase,t:normalisePath"`,
+			},
+		},
+		{
+			name: "POSITIVE - repeated decoding is not redundant",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:urlDecode,t:urlDecode"`,
+			},
+		},
+		{
+			name: "NEGATIVE - unknown transformation",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercas"`,
+			},
+			want: []finding{
+				{
+					Message: `unknown transformation "lowercas"`,
+					Lexeme:  "lowercas",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - unknown transformation of a lowercase directive",
+			contents: []string{
+				`secrule ARGS "@rx foo" "id:1,t:none,t:bogus"`,
+			},
+			want: []finding{
+				{
+					Message: `unknown transformation "bogus"`,
+					Lexeme:  "bogus",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - t:none is not first",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:lowercase,t:none"`,
+			},
+			want: []finding{
+				{
+					Message: "t:none discards the transformations listed before it, list t:none first",
+					Lexeme:  "t:none",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - appended to inherited transformations",
+			contents: []string{
+				`SecDefaultAction "phase:2,log,pass,t:urlDecode"`,
+				`SecRule ARGS "@rx foo" "id:1,phase:2,t:lowercase"`,
+			},
+			want: []finding{
+				{
+					Message: "transformations are appended to t:urlDecode inherited from SecDefaultAction, start the list with t:none",
+					Lexeme:  "t:lowercase",
+				},
+			},
+		},
+		{
+			name: "POSITIVE - inherited transformations of another phase",
+			contents: []string{
+				`SecDefaultAction "phase:1,log,pass,t:urlDecode"`,
+				`SecRule ARGS "@rx foo" "id:1,phase:2,t:lowercase"`,
+			},
+		},
+		{
+			name: "NEGATIVE - duplicated transformation",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase,t:lowercase"`,
+			},
+			want: []finding{
+				{
+					Message: "duplicate transformation t:lowercase has no effect",
+					Lexeme:  "t:lowercase",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - duplicated t:none is reported once",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:none,t:lowercase"`,
+			},
+			want: []finding{
+				{
+					Message: "duplicate transformation t:none has no effect",
+					Lexeme:  "t:none",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - t:none after another reset and a transformation",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase,t:none"`,
+			},
+			want: []finding{
+				{
+					Message: "t:none discards the transformations listed before it, list t:none first",
+					Lexeme:  "t:none",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - transformation repeated later in the pipeline",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase,t:trim,t:lowercase"`,
+			},
+			want: []finding{
+				{
+					Message: "duplicate transformation t:lowercase has no effect",
+					Lexeme:  "t:lowercase",
+				},
+			},
+		},
+		{
+			name: "POSITIVE - transformation repeated after a decoder",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:removeWhitespace,t:urlDecode,t:removeWhitespace"`,
+			},
+		},
+		{
+			name: "NEGATIVE - transformation repeated after the one overriding it",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase,t:trim,t:uppercase,t:lowercase"`,
+			},
+			want: []finding{
+				{
+					Message: "transformation t:uppercase has no effect, it is overridden by t:lowercase",
+					Lexeme:  "t:uppercase",
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - overridden transformation",
+			contents: []string{
+				`SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase,t:uppercase"`,
+			},
+			want: []finding{
+				{
+					Message: "transformation t:lowercase has no effect, it is overridden by t:uppercase",
+					Lexeme:  "t:lowercase",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runCheck(t, checkTransformations, tt.contents...)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
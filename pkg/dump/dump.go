@@ -0,0 +1,296 @@
+// Package dump renders the parse tree of a file as indented
+// text or JSON, for debugging the parser and building tooling
+package dump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// kinds of nodes within the dumped tree
+const (
+	KindFile      = "file"
+	KindDirective = "directive"
+	KindOption    = "option"
+	KindVariable  = "variable"
+	KindOperator  = "operator"
+	KindAction    = "action"
+	KindCtl       = "ctl"
+	KindSetvar    = "setvar"
+	// an error found while parsing the structure of an option
+	KindError = "error"
+)
+
+// represents a node of the dumped tree
+type Node struct {
+	// one of the node kinds
+	Kind string `json:"kind"`
+
+	// text of the node as written in the file, the
+	// name for files and the message for errors
+	Text string `json:"text"`
+
+	// offset of the node within the entire file
+	Offset int `json:"offset"`
+
+	// line of the node, counted from 1
+	Line int `json:"line"`
+
+	// column of the node in bytes, counted from 0
+	// the same way as the linter reports findings
+	Column int `json:"column"`
+
+	// parsed fields of the node, ex. "name" and "negated" of an operator
+	Attributes map[string]any `json:"attributes,omitempty"`
+
+	// child nodes, in order of offset
+	Children []*Node `json:"children,omitempty"`
+}
+
+// builds the tree of nodes of a single file
+type builder struct {
+	file *parse.File
+
+	// offsets at which each line starts
+	lineStarts []int
+}
+
+// Builds the dumped tree of the file, including the variables,
+// operator and actions of SecRule and action directives
+func Build(file *parse.File) *Node {
+	b := &builder{file: file, lineStarts: []int{0}}
+
+	for i, c := range file.Contents() {
+		if c == '\n' {
+			b.lineStarts = append(b.lineStarts, i+1)
+		}
+	}
+
+	root := b.node(KindFile, file.Name(), 0, nil)
+
+	for _, directive := range file.Directives {
+		root.Children = append(root.Children, b.directive(directive))
+	}
+
+	return root
+}
+
+// returns a node at the given offset
+func (b *builder) node(kind, text string, offset int, attributes map[string]any) *Node {
+	line, _ := slices.BinarySearch(b.lineStarts, offset+1)
+
+	return &Node{
+		Kind:       kind,
+		Text:       text,
+		Offset:     offset,
+		Line:       line,
+		Column:     offset - b.lineStarts[line-1],
+		Attributes: attributes,
+	}
+}
+
+// returns the nodes of the errors, which are either a single
+// linter error or an error wrapping linter errors
+func (b *builder) errors(err error) []*Node {
+	var nodes []*Node
+
+	for _, linterError := range parse.LinterErrors(err) {
+		nodes = append(nodes, b.node(KindError, linterError.Message, linterError.Offset, nil))
+	}
+
+	if len(nodes) == 0 && err != nil {
+		nodes = append(nodes, b.node(KindError, err.Error(), 0, nil))
+	}
+
+	return nodes
+}
+
+// returns the node of the directive and its options
+func (b *builder) directive(directive *parse.Directive) *Node {
+	node := b.node(KindDirective, directive.Lexeme, directive.Offset, nil)
+
+	for i, option := range directive.Options {
+		optionNode := b.node(KindOption, option.Lexeme, option.Offset, nil)
+
+		switch {
+		case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) > 1 && i == 0:
+			optionNode.Children = b.variables(option)
+		case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) > 1 && i == 1:
+			optionNode.Children = b.operator(option)
+		case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && i == 2,
+			strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction) && i == 0,
+			strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction) && i == 0:
+			optionNode.Children = b.actions(option)
+		}
+
+		node.Children = append(node.Children, optionNode)
+	}
+
+	return node
+}
+
+// returns the nodes of the variables held by the option
+func (b *builder) variables(option *parse.Option) []*Node {
+	variables, err := parse.ParseVariables(b.file.Contents(), option)
+	if err != nil {
+		return b.errors(err)
+	}
+
+	nodes := make([]*Node, 0, len(variables))
+
+	for _, variable := range variables {
+		nodes = append(nodes, b.node(KindVariable, variable.Lexeme, variable.Offset, map[string]any{
+			"name":    variable.Name,
+			"key":     variable.Key,
+			"count":   variable.Count,
+			"exclude": variable.Exclude,
+		}))
+	}
+
+	return nodes
+}
+
+// returns the node of the operator held by the option
+func (b *builder) operator(option *parse.Option) []*Node {
+	operator, err := parse.ParseOperator(b.file.Contents(), option)
+	if err != nil {
+		return b.errors(err)
+	}
+
+	text := operator.Argument
+	if !operator.Implicit {
+		text = string(b.file.Contents()[operator.Offset:operator.ArgumentOffset]) + operator.Argument
+	}
+
+	node := b.node(KindOperator, strings.TrimRight(text, " \t"), operator.Offset, map[string]any{
+		"name":     operator.Name,
+		"argument": operator.Argument,
+		"negated":  operator.Negated,
+		"implicit": operator.Implicit,
+	})
+
+	return []*Node{node}
+}
+
+// returns the nodes of the actions held by the option
+func (b *builder) actions(option *parse.Option) []*Node {
+	actions, err := parse.ParseActions(b.file.Contents(), option)
+	if err != nil {
+		return b.errors(err)
+	}
+
+	nodes := make([]*Node, 0, len(actions))
+
+	for _, action := range actions {
+		node := b.node(KindAction, action.Lexeme, action.Offset, map[string]any{
+			"name":  action.Name,
+			"value": action.Value,
+		})
+
+		switch strings.ToLower(action.Name) {
+		case parse.ActionCtl:
+			node.Children = b.ctl(action)
+		case parse.ActionSetVar:
+			node.Children = b.setvar(action)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// returns the node of the value of the ctl action
+func (b *builder) ctl(action *parse.Action) []*Node {
+	ctl, err := parse.ParseCtl(b.file.Contents(), action)
+	if err != nil {
+		return b.errors(err)
+	}
+
+	return []*Node{b.node(KindCtl, action.Value, ctl.Offset, map[string]any{
+		"option":   ctl.Option,
+		"argument": ctl.Argument,
+		"target":   ctl.Target,
+	})}
+}
+
+// returns the node of the value of the setvar action
+func (b *builder) setvar(action *parse.Action) []*Node {
+	setvar, err := parse.ParseSetvar(b.file.Contents(), action)
+	if err != nil {
+		return b.errors(err)
+	}
+
+	return []*Node{b.node(KindSetvar, action.Value, setvar.Offset, map[string]any{
+		"collection": setvar.Collection,
+		"key":        setvar.Key,
+		"operation":  setvar.Operation,
+		"value":      setvar.Value,
+	})}
+}
+
+// Writes the tree as indented text, one node per line
+// followed by its attributes sorted by name
+func Text(w io.Writer, root *Node) error {
+	var out strings.Builder
+
+	writeText(&out, root, 0)
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// writes the node and its children at the given depth
+func writeText(out *strings.Builder, node *Node, depth int) {
+	fmt.Fprintf(
+		out,
+		"%s%s %q offset=%d line=%d column=%d",
+		strings.Repeat("  ", depth),
+		node.Kind,
+		node.Text,
+		node.Offset,
+		node.Line,
+		node.Column,
+	)
+
+	names := make([]string, 0, len(node.Attributes))
+	for name := range node.Attributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch value := node.Attributes[name].(type) {
+		case string:
+			fmt.Fprintf(out, " %s=%q", name, value)
+		default:
+			fmt.Fprintf(out, " %s=%v", name, value)
+		}
+	}
+
+	out.WriteRune('\n')
+
+	for _, child := range node.Children {
+		writeText(out, child, depth+1)
+	}
+}
+
+// Writes the tree as indented JSON
+func JSON(w io.Writer, root *Node) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(root); err != nil {
+		return fmt.Errorf("could not encode tree: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/go-test/deep"
+)
+
+// joins the lines with line breaks, ending with a line break
+func joinLines(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{
+			name:     "POSITIVE - directive with options",
+			contents: "# comment\nSecMarker END",
+			want: joinLines(
+				`file "" offset=0 line=1 column=0`,
+				`  directive "SecMarker" offset=10 line=2 column=0`,
+				`    option "END" offset=20 line=2 column=10`,
+			),
+		},
+		{
+			name: "POSITIVE - rule with variables, operator and actions",
+			contents: "SecRule !ARGS:foo \"!@pm foo\" \\\n" +
+				"    \"id:1,setvar:tx.a=+1,ctl:ruleEngine=Off\"",
+			want: joinLines(
+				`file "" offset=0 line=1 column=0`,
+				`  directive "SecRule" offset=0 line=1 column=0`,
+				`    option "!ARGS:foo" offset=8 line=1 column=8`,
+				`      variable "!ARGS:foo" offset=8 line=1 column=8 count=false exclude=true key="foo" name="ARGS"`,
+				`    option "\"!@pm foo\"" offset=18 line=1 column=18`,
+				`      operator "@pm foo" offset=20 line=1 column=20 argument="foo" implicit=false name="pm" negated=true`,
+				`    option "\"id:1,setvar:tx.a=+1,ctl:ruleEngine=Off\"" offset=35 line=2 column=4`,
+				`      action "id:1" offset=36 line=2 column=5 name="id" value="1"`,
+				`      action "setvar:tx.a=+1" offset=41 line=2 column=10 name="setvar" value="tx.a=+1"`,
+				`        setvar "tx.a=+1" offset=48 line=2 column=17 collection="tx" key="a" operation="=+" value="1"`,
+				`      action "ctl:ruleEngine=Off" offset=56 line=2 column=25 name="ctl" value="ruleEngine=Off"`,
+				`        ctl "ruleEngine=Off" offset=60 line=2 column=29 argument="Off" option="ruleEngine" target=""`,
+			),
+		},
+		{
+			name:     "POSITIVE - implicit operator",
+			contents: `SecRule ARGS "^foo$" "id:1"`,
+			want: joinLines(
+				`file "" offset=0 line=1 column=0`,
+				`  directive "SecRule" offset=0 line=1 column=0`,
+				`    option "ARGS" offset=8 line=1 column=8`,
+				`      variable "ARGS" offset=8 line=1 column=8 count=false exclude=false key="" name="ARGS"`,
+				`    option "\"^foo$\"" offset=13 line=1 column=13`,
+				`      operator "^foo$" offset=14 line=1 column=14 argument="^foo$" implicit=true name="rx" negated=false`,
+				`    option "\"id:1\"" offset=21 line=1 column=21`,
+				`      action "id:1" offset=22 line=1 column=22 name="id" value="1"`,
+			),
+		},
+		{
+			name:     "POSITIVE - actions of a lowercase directive",
+			contents: `secaction "id:1"`,
+			want: joinLines(
+				`file "" offset=0 line=1 column=0`,
+				`  directive "secaction" offset=0 line=1 column=0`,
+				`    option "\"id:1\"" offset=10 line=1 column=10`,
+				`      action "id:1" offset=11 line=1 column=11 name="id" value="1"`,
+			),
+		},
+		{
+			name:     "NEGATIVE - option which does not parse holds an error",
+			contents: `SecAction "id:1,,pass"`,
+			want: joinLines(
+				`file "" offset=0 line=1 column=0`,
+				`  directive "SecAction" offset=0 line=1 column=0`,
+				`    option "\"id:1,,pass\"" offset=10 line=1 column=10`,
+				`      error "empty action in action list" offset=16 line=1 column=16`,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parse.Parse([]byte(tt.contents))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if err := Text(&out, Build(file)); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(out.String(), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	file, err := parse.Parse([]byte("SecMarker END"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := JSON(&out, Build(file)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got Node
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Node{
+		Kind: KindFile,
+		Line: 1,
+		Children: []*Node{
+			{
+				Kind: KindDirective,
+				Text: "SecMarker",
+				Line: 1,
+				Children: []*Node{
+					{Kind: KindOption, Text: "END", Offset: 10, Line: 1, Column: 10},
+				},
+			},
+		},
+	}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
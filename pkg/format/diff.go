@@ -0,0 +1,153 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lines of unchanged context around each hunk of a diff
+const diffContext = 3
+
+// represents a line of a diff
+type diffLine struct {
+	// one of ' ', '-' or '+'
+	kind byte
+
+	// text of the line without its newline
+	text string
+}
+
+// Returns the unified diff turning before into after, with both
+// sides labeled by the given name, empty if both are equal
+func Diff(name string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+
+	lines := diffLines(splitLines(string(before)), splitLines(string(after)))
+
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", name, name)
+
+	for start := 0; start < len(lines); {
+		// find the next change
+		for start < len(lines) && lines[start].kind == ' ' {
+			start++
+		}
+
+		if start == len(lines) {
+			break
+		}
+
+		hunkStart := max(start-diffContext, 0)
+
+		// extend the hunk while changes are close enough
+		// to share context
+		end := start
+		for unchanged := 0; end < len(lines) && unchanged <= 2*diffContext; end++ {
+			unchanged++
+			if lines[end].kind != ' ' {
+				unchanged = 0
+			}
+		}
+
+		hunkEnd := end
+		for hunkEnd > start && lines[hunkEnd-1].kind == ' ' {
+			hunkEnd--
+		}
+
+		hunkEnd = min(hunkEnd+diffContext, len(lines))
+
+		writeHunk(&out, lines, hunkStart, hunkEnd)
+
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+// writes the lines of the hunk along with its header
+func writeHunk(out *strings.Builder, lines []diffLine, start, end int) {
+	beforeLine, afterLine := 1, 1
+
+	for _, line := range lines[:start] {
+		if line.kind != '+' {
+			beforeLine++
+		}
+
+		if line.kind != '-' {
+			afterLine++
+		}
+	}
+
+	beforeCount, afterCount := 0, 0
+
+	for _, line := range lines[start:end] {
+		if line.kind != '+' {
+			beforeCount++
+		}
+
+		if line.kind != '-' {
+			afterCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", beforeLine, beforeCount, afterLine, afterCount)
+
+	for _, line := range lines[start:end] {
+		out.WriteByte(line.kind)
+		out.WriteString(line.text)
+		out.WriteByte('\n')
+	}
+}
+
+// returns the lines of both texts aligned by their
+// longest common subsequence
+func diffLines(before, after []string) []diffLine {
+	// common[i][j] holds the length of the longest common
+	// subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, max(len(before), len(after)))
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			lines = append(lines, diffLine{kind: ' ', text: before[i]})
+			i++
+			j++
+		case j == len(after) || (i < len(before) && common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, diffLine{kind: '-', text: before[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{kind: '+', text: after[j]})
+			j++
+		}
+	}
+
+	return lines
+}
+
+// splits the text into lines without their newlines
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,69 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "POSITIVE - equal contents",
+			before: joinLines("a", "b"),
+			after:  joinLines("a", "b"),
+			want:   "",
+		},
+		{
+			name:   "POSITIVE - changed line with context",
+			before: joinLines("a", "b", "c", "d", "e", "f", "g", "h", "i"),
+			after:  joinLines("a", "b", "c", "d", "E", "f", "g", "h", "i"),
+			want: joinLines(
+				"--- rules.conf",
+				"+++ rules.conf",
+				"@@ -2,7 +2,7 @@",
+				" b",
+				" c",
+				" d",
+				"-e",
+				"+E",
+				" f",
+				" g",
+				" h",
+			),
+		},
+		{
+			name:   "POSITIVE - distant changes in separate hunks",
+			before: joinLines("a", "b", "c", "d", "e", "f", "g", "h", "i", "j"),
+			after:  joinLines("A", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"),
+			want: joinLines(
+				"--- rules.conf",
+				"+++ rules.conf",
+				"@@ -1,4 +1,4 @@",
+				"-a",
+				"+A",
+				" b",
+				" c",
+				" d",
+				"@@ -8,3 +8,4 @@",
+				" h",
+				" i",
+				" j",
+				"+k",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff("rules.conf", []byte(tt.before), []byte(tt.after))
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// Reports whether both parsed files hold the same directives with
+// the same options. Action lists are compared in canonical order,
+// so files differing only in formatting are equivalent.
+func Equivalent(a, b *parse.File) (bool, error) {
+	if len(a.Directives) != len(b.Directives) {
+		return false, nil
+	}
+
+	for i := range a.Directives {
+		equivalent, err := equivalentDirectives(a, b, a.Directives[i], b.Directives[i])
+		if err != nil || !equivalent {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// reports whether both directives hold the same options
+func equivalentDirectives(fileA, fileB *parse.File, a, b *parse.Directive) (bool, error) {
+	if a.Lexeme != b.Lexeme || len(a.Options) != len(b.Options) {
+		return false, nil
+	}
+
+	actionsA, err := directiveActions(fileA, a)
+	if err != nil {
+		return false, err
+	}
+
+	actionsB, err := directiveActions(fileB, b)
+	if err != nil {
+		return false, err
+	}
+
+	if (actionsA == nil) != (actionsB == nil) {
+		return false, nil
+	}
+
+	options := len(a.Options)
+	if actionsA != nil {
+		options--
+	}
+
+	for i := range options {
+		if a.Options[i].Content() != b.Options[i].Content() {
+			return false, nil
+		}
+	}
+
+	return equivalentActions(sortActions(actionsA), sortActions(actionsB)), nil
+}
+
+// reports whether both action lists hold the same actions in order
+func equivalentActions(a, b []*parse.Action) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !strings.EqualFold(a[i].Name, b[i].Name) || a[i].Value != b[i].Value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// verifies that the formatted contents parse to
+// a file equivalent to the original file
+func verify(original *parse.File, formatted []byte) error {
+	reparsed, err := parse.Parse(formatted)
+	if err != nil {
+		return fmt.Errorf("formatted output of %s does not parse: %w", displayName(original), err)
+	}
+
+	equivalent, err := Equivalent(original, reparsed)
+	if err != nil {
+		return fmt.Errorf("formatted output of %s does not parse: %w", displayName(original), err)
+	}
+
+	if !equivalent {
+		return fmt.Errorf("formatted output of %s is not equivalent to the original", displayName(original))
+	}
+
+	return nil
+}
+
+// returns the name of the file for messages
+func displayName(file *parse.File) string {
+	if file.Name() == "" {
+		return "file"
+	}
+
+	return fmt.Sprintf("%q", file.Name())
+}
@@ -0,0 +1,59 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{
+			name: "POSITIVE - reordered and reformatted actions",
+			a:    `SecRule ARGS "@rx foo" "deny,id:1,t:none,t:lowercase"`,
+			b:    "SecRule ARGS \"@rx foo\" \\\n    \"id:1,\\\n    deny,\\\n    t:none,t:lowercase\"",
+			want: true,
+		},
+		{
+			name: "NEGATIVE - reordered transformations",
+			a:    `SecRule ARGS "@rx foo" "id:1,t:lowercase,t:none"`,
+			b:    `SecRule ARGS "@rx foo" "id:1,t:none,t:lowercase"`,
+		},
+		{
+			name: "NEGATIVE - different operator",
+			a:    `SecRule ARGS "@rx foo" "id:1"`,
+			b:    `SecRule ARGS "@rx bar" "id:1"`,
+		},
+		{
+			name: "NEGATIVE - different directive count",
+			a:    `SecMarker END`,
+			b:    "SecMarker END\nSecMarker END",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := parse.Parse([]byte(tt.a))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			b, err := parse.Parse([]byte(tt.b))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			got, err := Equivalent(a, b)
+			if err != nil {
+				t.Fatalf("Equivalent() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Equivalent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
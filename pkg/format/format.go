@@ -0,0 +1,210 @@
+// Package format pretty-prints parsed SecLang files in the
+// house style of the OWASP Core Ruleset.
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// indentation of continuation lines and chained rules
+const indent = "    "
+
+// Formats the given parsed file in CRS house style:
+//   - directive, targets and operator on the first line
+//   - one action per continuation line, indented by four spaces
+//   - transformations grouped on a single line
+//   - actions in canonical order
+//   - chained rules indented by four more spaces than their parent
+//
+// Comments and blank lines between directives are kept, and so are
+// "\r\n" line endings. The formatted output is verified to parse to
+// an equivalent file.
+func Format(file *parse.File) ([]byte, error) {
+	var (
+		out      strings.Builder
+		contents = string(file.Contents())
+		previous = 0
+
+		// line ending written by the formatter, files edited on
+		// Windows keep the line ending of their first line
+		newline = "\n"
+
+		// number of rules chained before the current one
+		links = 0
+	)
+
+	if end := strings.IndexByte(contents, '\n'); end > 0 && contents[end-1] == '\r' {
+		newline = "\r\n"
+	}
+
+	for i, directive := range file.Directives {
+		out.WriteString(formatTrivia(contents[previous:directive.Offset], i == 0, newline))
+
+		actions, err := directiveActions(file, directive)
+		if err != nil {
+			return nil, err
+		}
+
+		depth := strings.Repeat(indent, links)
+
+		out.WriteString(depth)
+		out.WriteString(formatDirective(directive, actions, depth, newline))
+
+		links = 0
+		if strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && hasAction(actions, parse.ActionChain) {
+			links = len(depth)/len(indent) + 1
+		}
+		previous = directive.Offset + directive.Len()
+	}
+
+	out.WriteString(formatTrivia(contents[previous:], len(file.Directives) == 0, newline))
+
+	formatted := strings.TrimRight(out.String(), newline) + newline
+	if strings.TrimSpace(formatted) == "" {
+		formatted = ""
+	}
+
+	if err := verify(file, []byte(formatted)); err != nil {
+		return nil, err
+	}
+
+	return []byte(formatted), nil
+}
+
+// formats the comments and whitespace between two directives,
+// trimming trailing whitespace and the indentation of comments
+func formatTrivia(trivia string, first bool, newline string) string {
+	lines := strings.Split(trivia, "\n")
+
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+
+	// the directive starts on its own line
+	if !first && len(lines) == 1 {
+		return newline
+	}
+
+	// text on the line of the previous directive stays there
+	if !first && lines[0] != "" {
+		lines[0] = " " + lines[0]
+	}
+
+	formatted := strings.Join(lines, newline)
+
+	if first {
+		formatted = strings.TrimLeft(formatted, newline)
+	}
+
+	return formatted
+}
+
+// formats a single directive, with continuation lines indented
+// relative to the given depth. Line breaks within options are
+// kept as written, only the added ones use the given line ending
+func formatDirective(directive *parse.Directive, actions []*parse.Action, depth, newline string) string {
+	options := make([]string, 0, len(directive.Options))
+	for _, option := range directive.Options {
+		options = append(options, option.Lexeme)
+	}
+
+	switch {
+	case actions == nil:
+		return directive.Lexeme + " " + strings.Join(options, " ")
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule):
+		return fmt.Sprintf(
+			"%s %s \\%s%s%s",
+			directive.Lexeme,
+			strings.Join(options[:2], " "),
+			newline,
+			depth+indent,
+			formatActions(actions, depth+indent, newline),
+		)
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction):
+		return fmt.Sprintf(
+			"%s \\%s%s%s",
+			directive.Lexeme,
+			newline,
+			depth+indent,
+			formatActions(actions, depth+indent, newline),
+		)
+	default:
+		return fmt.Sprintf("%s %s", directive.Lexeme, formatActionList(actions))
+	}
+}
+
+// formats the actions as a quoted list with one action per
+// continuation line, grouping consecutive transformations
+func formatActions(actions []*parse.Action, depth, newline string) string {
+	var lines []string
+
+	sorted := sortActions(actions)
+
+	for i, action := range sorted {
+		if i > 0 && isTransformation(action) && isTransformation(sorted[i-1]) {
+			lines[len(lines)-1] += "," + action.Lexeme
+
+			continue
+		}
+
+		lines = append(lines, action.Lexeme)
+	}
+
+	return `"` + strings.Join(lines, ",\\"+newline+depth) + `"`
+}
+
+// formats the actions as a quoted list on a single line
+func formatActionList(actions []*parse.Action) string {
+	lexemes := make([]string, 0, len(actions))
+	for _, action := range sortActions(actions) {
+		lexemes = append(lexemes, action.Lexeme)
+	}
+
+	return `"` + strings.Join(lexemes, ",") + `"`
+}
+
+// returns the parsed actions of the directive, nil if the
+// directive does not hold an action list
+func directiveActions(file *parse.File, directive *parse.Directive) ([]*parse.Action, error) {
+	var option *parse.Option
+
+	switch {
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) == 3:
+		option = directive.Options[2]
+	case (strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction) || strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction)) &&
+		len(directive.Options) == 1:
+		option = directive.Options[0]
+	default:
+		return nil, nil
+	}
+
+	actions, err := parse.ParseActions(file.Contents(), option)
+	if err != nil {
+		return nil, fmt.Errorf("could not format %s: %w", directive.Lexeme, err)
+	}
+
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	return actions, nil
+}
+
+// reports whether the action is a transformation
+func isTransformation(action *parse.Action) bool {
+	return strings.EqualFold(action.Name, parse.ActionT)
+}
+
+// reports whether the actions contain an action with the given name
+func hasAction(actions []*parse.Action, name string) bool {
+	for _, action := range actions {
+		if strings.EqualFold(action.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/go-test/deep"
+)
+
+func joinLines(lines ...string) string {
+	var joined string
+	for _, line := range lines {
+		joined += line + "\n"
+	}
+
+	return joined
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "POSITIVE - single line rule",
+			contents: `SecRule ARGS "@rx foo" "t:none,deny,id:1,msg:'Foo, found',t:lowercase,phase:2"`,
+			want: joinLines(
+				`SecRule ARGS "@rx foo" \`,
+				`    "id:1,\`,
+				`    phase:2,\`,
+				`    deny,\`,
+				`    t:none,t:lowercase,\`,
+				`    msg:'Foo, found'"`,
+			),
+		},
+		{
+			name:     "POSITIVE - lowercase directive",
+			contents: `secaction "pass,id:1"`,
+			want: joinLines(
+				`secaction \`,
+				`    "id:1,\`,
+				`    pass"`,
+			),
+		},
+		{
+			name: "POSITIVE - chained rules are indented",
+			contents: joinLines(
+				`SecRule ARGS "@rx foo" "id:1,phase:2,chain,deny"`,
+				`SecRule ARGS_NAMES "@rx bar" "chain"`,
+				`  SecRule REQUEST_URI "@rx baz" "setvar:tx.a=1"`,
+			),
+			want: joinLines(
+				`SecRule ARGS "@rx foo" \`,
+				`    "id:1,\`,
+				`    phase:2,\`,
+				`    deny,\`,
+				`    chain"`,
+				`    SecRule ARGS_NAMES "@rx bar" \`,
+				`        "chain"`,
+				`        SecRule REQUEST_URI "@rx baz" \`,
+				`            "setvar:tx.a=1"`,
+			),
+		},
+		{
+			name: "POSITIVE - comments and blank lines are kept",
+			contents: joinLines(
+				`# header   `,
+				``,
+				`SecAction "id:1,pass,phase:1,nolog"`,
+				``,
+				`   # comment`,
+				`SecMarker   END`,
+				``,
+				``,
+			),
+			want: joinLines(
+				`# header`,
+				``,
+				`SecAction \`,
+				`    "id:1,\`,
+				`    phase:1,\`,
+				`    pass,\`,
+				`    nolog"`,
+				``,
+				`# comment`,
+				`SecMarker END`,
+			),
+		},
+		{
+			name:     "POSITIVE - default actions stay on one line",
+			contents: `SecDefaultAction "log,phase:1,pass,auditlog"`,
+			want:     joinLines(`SecDefaultAction "phase:1,pass,log,auditlog"`),
+		},
+		{
+			name:     "POSITIVE - unknown actions sort before chain",
+			contents: `SecRule ARGS "@rx foo" "chain,rev:2,id:1"` + "\n" + `SecRule ARGS "@rx bar"`,
+			want: joinLines(
+				`SecRule ARGS "@rx foo" \`,
+				`    "id:1,\`,
+				`    rev:2,\`,
+				`    chain"`,
+				`    SecRule ARGS "@rx bar"`,
+			),
+		},
+		{
+			name:     "POSITIVE - windows line endings are kept",
+			contents: "# rule\r\nSecRule ARGS \"@rx foo\" \\\r\n  \"deny,id:1\"\r\n",
+			want:     "# rule\r\nSecRule ARGS \"@rx foo\" \\\r\n    \"id:1,\\\r\n    deny\"\r\n",
+		},
+		{
+			name:     "POSITIVE - windows line endings within an option are kept",
+			contents: "SecRule ARGS \"@rx foo\\\r\n  bar\" \"id:1,deny\"\r\n",
+			want:     "SecRule ARGS \"@rx foo\\\r\n  bar\" \\\r\n    \"id:1,\\\r\n    deny\"\r\n",
+		},
+		{
+			name:     "NEGATIVE - invalid action list",
+			contents: `SecAction "id:1,,pass"`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parse.Parse([]byte(tt.contents))
+			if err != nil {
+				t.Fatalf("could not parse test contents: %v", err)
+			}
+
+			got, err := Format(file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Format() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := deep.Equal(string(got), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
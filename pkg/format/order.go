@@ -0,0 +1,66 @@
+package format
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// action names in CRS house style order, actions sharing an entry
+// keep the order they are written in
+var actionOrder = [][]string{
+	{parse.ActionID},
+	{parse.ActionPhase},
+	{parse.ActionAllow, parse.ActionBlock, parse.ActionDeny, parse.ActionDrop, parse.ActionPass, "proxy", parse.ActionRedirect},
+	{parse.ActionStatus},
+	{parse.ActionCapture},
+	{parse.ActionT},
+	{parse.ActionLog},
+	{parse.ActionNoLog},
+	{parse.ActionAuditLog},
+	{parse.ActionNoAuditLog},
+	{parse.ActionMsg},
+	{parse.ActionLogData},
+	{parse.ActionTag},
+	{"sanitiseArg", "sanitiseRequestHeader", "sanitiseMatched", "sanitiseMatchedBytes"},
+	{parse.ActionCtl},
+	{parse.ActionVer},
+	{parse.ActionSeverity},
+	{parse.ActionMultiMatch},
+	{parse.ActionInitCol},
+	{parse.ActionSetEnv},
+	{parse.ActionSetVar},
+	{parse.ActionExpireVar},
+	{parse.ActionChain},
+	{parse.ActionSkip},
+	{parse.ActionSkipAfter},
+}
+
+// returns the position of the action within the canonical order,
+// actions missing from actionOrder sort after expirevar and before chain
+func actionRank(action *parse.Action) int {
+	for rank, names := range actionOrder {
+		if slices.ContainsFunc(names, func(name string) bool {
+			return strings.EqualFold(action.Name, name)
+		}) {
+			// ranks are doubled to leave room for missing actions
+			return 2 * rank
+		}
+	}
+
+	return 2*slices.IndexFunc(actionOrder, func(names []string) bool {
+		return names[0] == parse.ActionChain
+	}) - 1
+}
+
+// returns a copy of the actions in canonical order
+func sortActions(actions []*parse.Action) []*parse.Action {
+	sorted := slices.Clone(actions)
+
+	slices.SortStableFunc(sorted, func(a, b *parse.Action) int {
+		return actionRank(a) - actionRank(b)
+	})
+
+	return sorted
+}
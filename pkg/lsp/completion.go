@@ -0,0 +1,48 @@
+package lsp
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// responds with the directive names when the cursor is
+// at the start of a line, and with no items otherwise
+func (s *Server) completion(params json.RawMessage) (any, error) {
+	doc, offset, err := s.documentAt(params)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []completionItem{}
+
+	lineStart := strings.LastIndexByte(doc.text[:offset], '\n') + 1
+	prefix := strings.TrimLeft(doc.text[lineStart:offset], " \t")
+
+	if strings.ContainsFunc(prefix, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		return items, nil
+	}
+
+	// a line continued by the previous one is an option, not a directive
+	if parse.EndsWithContinuation(doc.text[:lineStart]) {
+		return items, nil
+	}
+
+	for _, lexeme := range parse.DirectiveLexemes() {
+		if !strings.HasPrefix(strings.ToLower(lexeme), strings.ToLower(prefix)) {
+			continue
+		}
+
+		detail, _ := lookupDoc(directiveDocs, lexeme)
+
+		items = append(items, completionItem{
+			Label:  lexeme,
+			Kind:   completionItemKeyword,
+			Detail: detail,
+		})
+	}
+
+	return items, nil
+}
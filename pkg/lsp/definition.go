@@ -0,0 +1,130 @@
+package lsp
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// responds with the markers of skipAfter actions and the
+// rules referenced by ID under the cursor
+func (s *Server) definition(params json.RawMessage) (any, error) {
+	doc, offset, err := s.documentAt(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := s.loadWorkspace(doc)
+	if err != nil {
+		// the parse error is already published as a diagnostic
+		return nil, nil
+	}
+
+	directive := directiveAt(ws.file, offset)
+	if directive == nil {
+		return nil, nil
+	}
+
+	// directive names are case insensitive, CRS writes "SecRuleRemoveById"
+	switch {
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleRemoveByID):
+		for _, option := range directive.Options {
+			for _, w := range option.Words() {
+				if within(offset, w.Offset, w.Offset+len(w.Text)) {
+					return ws.ruleLocations(doc, w.Text), nil
+				}
+			}
+		}
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRuleUpdateTargetByID):
+		if len(directive.Options) > 0 {
+			for _, w := range directive.Options[0].Words() {
+				if within(offset, w.Offset, w.Offset+len(w.Text)) {
+					return ws.ruleLocations(doc, w.Text), nil
+				}
+			}
+		}
+	}
+
+	for _, action := range actionsAt(ws.file, directive) {
+		if !within(offset, action.ValueOffset, action.ValueOffset+len(action.Value)) {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(action.Name, parse.ActionSkipAfter):
+			return ws.markerLocations(doc, action.Value), nil
+		case strings.EqualFold(action.Name, parse.ActionCtl):
+			ctl, err := parse.ParseCtl(ws.file.Contents(), action)
+			if err != nil {
+				return nil, nil
+			}
+
+			if strings.EqualFold(ctl.Option, "ruleRemoveById") ||
+				strings.EqualFold(ctl.Option, "ruleRemoveTargetById") {
+				return ws.ruleLocations(doc, ctl.Argument), nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// returns the locations of the SecMarker directives with the given name
+func (ws *workspace) markerLocations(doc *document, name string) []Location {
+	locations := []Location{}
+
+	for _, file := range ws.files {
+		for _, directive := range file.Directives {
+			if !strings.EqualFold(directive.Lexeme, parse.DirectiveSecMarker) || len(directive.Options) == 0 {
+				continue
+			}
+
+			option := directive.Options[0]
+			if strings.Trim(option.Content(), `'`) != name {
+				continue
+			}
+
+			locations = append(locations, Location{
+				URI:   ws.uri(doc, file),
+				Range: rangeOf(string(file.Contents()), option.Offset, option.Offset+option.Len()),
+			})
+		}
+	}
+
+	return locations
+}
+
+// returns the locations of the id actions of the rules matching
+// the given ID or ID range, ex. "942100" or "942100-942199"
+func (ws *workspace) ruleLocations(doc *document, reference string) []Location {
+	locations := []Location{}
+
+	ids, ok := parse.ParseIDRange(reference)
+	if !ok {
+		return locations
+	}
+
+	for _, file := range ws.files {
+		for _, directive := range file.Directives {
+			for _, action := range actionsAt(file, directive) {
+				if !strings.EqualFold(action.Name, parse.ActionID) {
+					continue
+				}
+
+				id, err := strconv.Atoi(action.Value)
+				if err != nil || !ids.Contains(id) {
+					continue
+				}
+
+				locations = append(locations, Location{
+					URI:   ws.uri(doc, file),
+					Range: rangeOf(string(file.Contents()), action.Offset, action.Offset+action.Len()),
+				})
+			}
+		}
+	}
+
+	return locations
+}
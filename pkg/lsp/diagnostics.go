@@ -0,0 +1,187 @@
+package lsp
+
+import (
+	"path/filepath"
+
+	"github.com/bak-minsu/seclang-linter/pkg/analyze"
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// name of the linter reported as the source of diagnostics
+const diagnosticSource = "seclang-linter"
+
+// represents a parsed document along with the files of its
+// directory, which Coraza usually loads together
+type workspace struct {
+	// the parsed document, its name is always empty
+	file *parse.File
+
+	// the document and the files next to it in load order
+	files []*parse.File
+}
+
+// severities of diagnostics, keyed by parse level
+var severities = map[int]int{
+	parse.ParseLevelError:   severityError,
+	parse.ParseLevelWarning: severityWarning,
+	parse.ParseLevelInfo:    severityInformation,
+	parse.ParseLevelHint:    severityHint,
+}
+
+// caches the files next to the open documents as parsed from disk,
+// so a change to a document does not parse its whole directory again
+type siblingCache struct {
+	// sorted paths of the files matching a glob pattern, keyed by pattern
+	matches map[string][]string
+
+	// parsed files keyed by path, nil for files which do not parse
+	files map[string]*parse.File
+}
+
+// creates an empty cache
+func newSiblingCache() *siblingCache {
+	return &siblingCache{
+		matches: map[string][]string{},
+		files:   map[string]*parse.File{},
+	}
+}
+
+// returns the sorted paths of the files matching the pattern
+func (c *siblingCache) glob(pattern string) []string {
+	matches, ok := c.matches[pattern]
+	if !ok {
+		matches, _ = filepath.Glob(pattern)
+		c.matches[pattern] = matches
+	}
+
+	return matches
+}
+
+// returns the parsed file at the path, nil if it does not parse
+func (c *siblingCache) parse(path string) *parse.File {
+	path = filepath.Clean(path)
+
+	file, ok := c.files[path]
+	if !ok {
+		file, _ = parse.ParseFile(path)
+		c.files[path] = file
+	}
+
+	return file
+}
+
+// forgets the file at the path along with the listings of its
+// directory, after the file was saved, created or deleted
+func (c *siblingCache) invalidate(path string) {
+	path = filepath.Clean(path)
+
+	delete(c.files, path)
+
+	for pattern := range c.matches {
+		if filepath.Dir(pattern) == filepath.Dir(path) {
+			delete(c.matches, pattern)
+		}
+	}
+}
+
+// parses the document and the files next to it sharing its extension
+func (s *Server) loadWorkspace(doc *document) (*workspace, error) {
+	file, err := parse.Parse([]byte(doc.text))
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &workspace{file: file}
+
+	path := doc.path()
+	if path == "" || filepath.Ext(path) == "" {
+		ws.files = []*parse.File{file}
+
+		return ws, nil
+	}
+
+	// glob matches are sorted, matching the load order of Include
+	matches := s.siblings.glob(filepath.Join(filepath.Dir(path), "*"+filepath.Ext(path)))
+
+	inserted := false
+
+	for _, match := range matches {
+		if filepath.Clean(match) == filepath.Clean(path) {
+			continue
+		}
+
+		if !inserted && match > path {
+			ws.files = append(ws.files, file)
+			inserted = true
+		}
+
+		// files which do not parse are reported when opened
+		if sibling := s.siblings.parse(match); sibling != nil {
+			ws.files = append(ws.files, sibling)
+		}
+	}
+
+	if !inserted {
+		ws.files = append(ws.files, file)
+	}
+
+	return ws, nil
+}
+
+// returns the URI of the given file of the workspace
+func (ws *workspace) uri(doc *document, file *parse.File) string {
+	if file == ws.file {
+		return doc.uri
+	}
+
+	return pathURI(file.Name())
+}
+
+// parses and analyzes the document, returning its findings
+func (s *Server) diagnose(doc *document) []Diagnostic {
+	ws, err := s.loadWorkspace(doc)
+	if err == nil {
+		options := analyze.Options{}
+		if path := doc.path(); path != "" {
+			options.DataRoot = filepath.Dir(path)
+		}
+
+		err = analyze.Analyze(options, ws.files...)
+	}
+
+	if err == nil {
+		return []Diagnostic{}
+	}
+
+	linterErrors := parse.LinterErrors(err)
+	if len(linterErrors) == 0 {
+		return []Diagnostic{{Severity: severityError, Source: diagnosticSource, Message: err.Error()}}
+	}
+
+	diagnostics := make([]Diagnostic, 0, len(linterErrors))
+
+	for _, linterError := range linterErrors {
+		// findings of other files are reported when they are opened
+		if linterError.File != "" {
+			continue
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    rangeOf(doc.text, linterError.Offset, linterError.OffsetEnd()),
+			Severity: severities[linterError.ParseLevel],
+			Code:     linterError.Code(),
+			Source:   diagnosticSource,
+			Message:  linterError.Message,
+		})
+	}
+
+	return diagnostics
+}
+
+// publishes the findings of the document to the client
+func (s *Server) publishDiagnostics(doc *document) error {
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         doc.uri,
+		Diagnostics: s.diagnose(doc),
+	})
+}
@@ -0,0 +1,180 @@
+package lsp
+
+import (
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// documentation of directives shown on hover
+var directiveDocs = map[string]string{
+	parse.DirectiveInclude:                     "Loads the directives of the given file or glob pattern, in alphabetical order.",
+	parse.DirectiveSecAction:                   "Unconditionally runs the given actions, as a rule matching every transaction.",
+	parse.DirectiveSecArgumentsLimit:           "Limits the number of arguments parsed from the request.",
+	parse.DirectiveSecAuditEngine:              "Configures audit logging: `On`, `Off` or `RelevantOnly`.",
+	parse.DirectiveSecAuditLog:                 "Path of the main audit log file.",
+	parse.DirectiveSecAuditLogDir:              "Directory audit log entries are written to when using the concurrent log type.",
+	parse.DirectiveSecAuditLogDirMode:          "Permissions of directories created for concurrent audit logs.",
+	parse.DirectiveSecAuditLogFileMode:         "Permissions of files created for concurrent audit logs.",
+	parse.DirectiveSecAuditLogFormat:           "Format of audit log entries, ex. `JSON` or `Native`.",
+	parse.DirectiveSecAuditLogParts:            "Parts of the transaction written to the audit log, ex. `ABIJDEFHZ`.",
+	parse.DirectiveSecAuditLogRelevantStatus:   "Regular expression of response statuses considered relevant for audit logging.",
+	parse.DirectiveSecDebugLog:                 "Path of the debug log file.",
+	parse.DirectiveSecDebugLogLevel:            "Verbosity of the debug log, from 0 to 9.",
+	parse.DirectiveSecDefaultAction:            "Actions inherited by the rules of the same phase declared after this directive.",
+	parse.DirectiveSecMarker:                   "Declares a marker which `skipAfter` actions jump to.",
+	parse.DirectiveSecRequestBodyAccess:        "Enables buffering and inspection of request bodies.",
+	parse.DirectiveSecRequestBodyInMemoryLimit: "Maximum size of a request body kept in memory before it is buffered to disk.",
+	parse.DirectiveSecRequestBodyLimit:         "Maximum size of a request body accepted for inspection.",
+	parse.DirectiveSecRequestBodyLimitAction:   "Behavior when the request body limit is reached: `Reject` or `ProcessPartial`.",
+	parse.DirectiveSecRequestBodyNoFilesLimit:  "Maximum size of a request body, excluding uploaded files.",
+	parse.DirectiveSecResponseBodyAccess:       "Enables buffering and inspection of response bodies.",
+	parse.DirectiveSecResponseBodyLimit:        "Maximum size of a response body accepted for inspection.",
+	parse.DirectiveSecResponseBodyLimitAction:  "Behavior when the response body limit is reached: `Reject` or `ProcessPartial`.",
+	parse.DirectiveSecRule:                     "Inspects the given variables with an operator and runs the actions when it matches.\n\n`SecRule VARIABLES \"@operator argument\" \"actions\"`",
+	parse.DirectiveSecRuleEngine:               "Configures rule processing: `On`, `Off` or `DetectionOnly`.",
+	parse.DirectiveSecRuleRemoveByID:           "Removes the rules with the given IDs or ID ranges declared before it.",
+	parse.DirectiveSecRuleRemoveByTag:          "Removes the rules with the given tag declared before it.",
+	parse.DirectiveSecRuleUpdateTargetByID:     "Changes the variables inspected by the rule with the given ID.",
+	parse.DirectiveSecRuleUpdateTargetByTag:    "Changes the variables inspected by the rules with the given tag.",
+}
+
+// documentation of actions shown on hover
+var actionDocs = map[string]string{
+	parse.ActionAccuracy:   "Metadata: accuracy level of the rule, from 1 to 9.",
+	parse.ActionAllow:      "Disruptive: stops rule processing and allows the transaction.",
+	parse.ActionAuditLog:   "Logs the transaction to the audit log when the rule matches.",
+	parse.ActionBlock:      "Disruptive: applies the disruptive action inherited from `SecDefaultAction`.",
+	parse.ActionCapture:    "Stores regular expression captures in `TX:0` to `TX:9`.",
+	parse.ActionChain:      "Chains the next rule, the chain matches only when every rule in it matches.",
+	parse.ActionCtl:        "Changes the configuration for the current transaction, ex. `ctl:ruleRemoveById=942100`.",
+	parse.ActionDeny:       "Disruptive: stops rule processing and rejects the transaction.",
+	parse.ActionDrop:       "Disruptive: closes the connection.",
+	parse.ActionExec:       "Runs an external script when the rule matches.",
+	parse.ActionExpireVar:  "Sets the time after which a persistent variable expires.",
+	parse.ActionID:         "Unique ID of the rule, required by every rule starting a chain.",
+	parse.ActionInitCol:    "Initializes a persistent collection.",
+	parse.ActionLog:        "Logs the match to the error log.",
+	parse.ActionLogData:    "Data written to the log along with the match, macros are expanded.",
+	parse.ActionMaturity:   "Metadata: maturity level of the rule, from 1 to 9.",
+	parse.ActionMsg:        "Message logged when the rule matches, macros are expanded.",
+	parse.ActionMultiMatch: "Runs the operator after every transformation instead of only after the last one.",
+	parse.ActionNoAuditLog: "Prevents the match from being written to the audit log.",
+	parse.ActionNoLog:      "Prevents the match from being logged.",
+	parse.ActionPass:       "Disruptive: continues with the next rule after a match.",
+	parse.ActionPhase:      "Phase the rule runs in, from 1 to 5. Defaults to 2.",
+	parse.ActionRedirect:   "Disruptive: redirects the client to the given URL.",
+	parse.ActionRev:        "Metadata: revision of the rule.",
+	parse.ActionSetEnv:     "Sets an environment variable.",
+	parse.ActionSetRSC:     "Initializes the `RESOURCE` collection.",
+	parse.ActionSetSID:     "Initializes the `SESSION` collection.",
+	parse.ActionSetUID:     "Initializes the `USER` collection.",
+	parse.ActionSetVar:     "Creates, changes or removes a collection variable, ex. `setvar:'tx.score=+5'`.",
+	parse.ActionSeverity:   "Metadata: severity of the rule, ex. `CRITICAL`.",
+	parse.ActionSkip:       "Skips the given number of rules after a match.",
+	parse.ActionSkipAfter:  "Skips every rule until the given `SecMarker` after a match.",
+	parse.ActionStatus:     "Response status used by the `deny` and `redirect` disruptive actions.",
+	parse.ActionT:          "Transformation applied to variables before the operator runs, ex. `t:lowercase`.",
+	parse.ActionTag:        "Metadata: tag of the rule, used by exclusions such as `SecRuleRemoveByTag`.",
+	parse.ActionVer:        "Metadata: version of the rule set the rule belongs to.",
+}
+
+// documentation of operators shown on hover
+var operatorDocs = map[string]string{
+	parse.OperatorBeginsWith:           "Matches when the input begins with the argument.",
+	parse.OperatorContains:             "Matches when the input contains the argument.",
+	parse.OperatorContainsWord:         "Matches when the input contains the argument as a whole word.",
+	parse.OperatorDetectSQLi:           "Matches when libinjection detects SQL injection in the input.",
+	parse.OperatorDetectXSS:            "Matches when libinjection detects cross site scripting in the input.",
+	parse.OperatorEndsWith:             "Matches when the input ends with the argument.",
+	parse.OperatorEq:                   "Matches when the input equals the integer argument.",
+	parse.OperatorGe:                   "Matches when the input is greater than or equal to the integer argument.",
+	parse.OperatorGeoLookup:            "Looks up the input IP address and populates the `GEO` collection.",
+	parse.OperatorGt:                   "Matches when the input is greater than the integer argument.",
+	parse.OperatorInspectFile:          "Runs an external script on uploaded files.",
+	parse.OperatorIPMatch:              "Matches when the input IP address is within one of the comma separated IPs or CIDRs.",
+	parse.OperatorIPMatchF:             "Alias of `@ipMatchFromFile`.",
+	parse.OperatorIPMatchFromFile:      "Matches when the input IP address is within one of the IPs or CIDRs of the data file.",
+	parse.OperatorLe:                   "Matches when the input is less than or equal to the integer argument.",
+	parse.OperatorLt:                   "Matches when the input is less than the integer argument.",
+	parse.OperatorNoMatch:              "Never matches.",
+	parse.OperatorPm:                   "Matches when the input contains one of the space separated phrases, case insensitive.",
+	parse.OperatorPmf:                  "Alias of `@pmFromFile`.",
+	parse.OperatorPmFromFile:           "Matches when the input contains one of the phrases of the data file, case insensitive.",
+	parse.OperatorRbl:                  "Matches when the input IP address is listed by the real-time block list.",
+	parse.OperatorRestpath:             "Matches the input path against a REST path template and populates `ARGS_PATH`.",
+	parse.OperatorRx:                   "Matches the input against the regular expression, the default operator.",
+	parse.OperatorStreq:                "Matches when the input equals the argument.",
+	parse.OperatorStrmatch:             "Matches when the input contains the argument.",
+	parse.OperatorUnconditionalMatch:   "Always matches.",
+	parse.OperatorValidateByteRange:    "Matches when the input contains bytes outside the comma separated bytes and ranges.",
+	parse.OperatorValidateNid:          "Matches when the input contains a valid national ID, ex. `cl`.",
+	parse.OperatorValidateSchema:       "Matches when the XML or JSON body does not validate against the schema.",
+	parse.OperatorValidateURLEncoding:  "Matches when the input is not valid URL encoding.",
+	parse.OperatorValidateUTF8Encoding: "Matches when the input is not valid UTF-8.",
+	parse.OperatorWithin:               "Matches when the input is found within the argument.",
+}
+
+// documentation of variables shown on hover
+var variableDocs = map[string]string{
+	parse.VariableArgs:                "Collection of query string and request body arguments.",
+	parse.VariableArgsCombinedSize:    "Combined size of all arguments.",
+	parse.VariableArgsGet:             "Collection of query string arguments.",
+	parse.VariableArgsGetNames:        "Names of the query string arguments.",
+	parse.VariableArgsNames:           "Names of the query string and request body arguments.",
+	parse.VariableArgsPath:            "Arguments populated by `@restpath`.",
+	parse.VariableArgsPost:            "Collection of request body arguments.",
+	parse.VariableArgsPostNames:       "Names of the request body arguments.",
+	parse.VariableDuration:            "Milliseconds elapsed since the transaction started.",
+	parse.VariableEnv:                 "Collection of environment variables set by `setenv`.",
+	parse.VariableFiles:               "Original names of uploaded files.",
+	parse.VariableFilesNames:          "Form field names of uploaded files.",
+	parse.VariableFilesSizes:          "Sizes of uploaded files.",
+	parse.VariableGeo:                 "Collection populated by `@geoLookup`.",
+	parse.VariableGlobal:              "Persistent collection shared by every transaction.",
+	parse.VariableIP:                  "Persistent collection of the client IP address.",
+	parse.VariableMatchedVar:          "Value of the variable matched last.",
+	parse.VariableMatchedVarName:      "Name of the variable matched last.",
+	parse.VariableMatchedVars:         "Values of all variables matched by the current rule.",
+	parse.VariableMatchedVarsNames:    "Names of all variables matched by the current rule.",
+	parse.VariableQueryString:         "Raw query string of the request.",
+	parse.VariableRemoteAddr:          "IP address of the client.",
+	parse.VariableRemotePort:          "Source port of the client.",
+	parse.VariableReqBodyError:        "Set to 1 when the request body could not be processed.",
+	parse.VariableReqBodyProcessor:    "Name of the request body processor, ex. `JSON`.",
+	parse.VariableRequestBasename:     "File name part of the request path.",
+	parse.VariableRequestBody:         "Raw request body, populated in phase 2.",
+	parse.VariableRequestBodyLength:   "Length of the request body.",
+	parse.VariableRequestCookies:      "Collection of request cookies.",
+	parse.VariableRequestCookiesNames: "Names of the request cookies.",
+	parse.VariableRequestFilename:     "Request path without the query string.",
+	parse.VariableRequestHeaders:      "Collection of request headers.",
+	parse.VariableRequestHeadersNames: "Names of the request headers.",
+	parse.VariableRequestLine:         "Request line, ex. `GET /index.html HTTP/1.1`.",
+	parse.VariableRequestMethod:       "Request method, ex. `GET`.",
+	parse.VariableRequestProtocol:     "Request protocol, ex. `HTTP/1.1`.",
+	parse.VariableRequestURI:          "Request URI including the query string.",
+	parse.VariableRequestURIRaw:       "Request URI as sent by the client.",
+	parse.VariableResource:            "Persistent collection initialized by `setrsc`.",
+	parse.VariableResponseBody:        "Raw response body, populated in phase 4.",
+	parse.VariableResponseContentType: "Content type of the response.",
+	parse.VariableResponseHeaders:     "Collection of response headers, populated in phase 3.",
+	parse.VariableResponseStatus:      "Response status code.",
+	parse.VariableRule:                "Metadata of the current rule, ex. `RULE.id`.",
+	parse.VariableSession:             "Persistent collection initialized by `setsid`.",
+	parse.VariableTX:                  "Transient collection of the transaction, set by `setvar`.",
+	parse.VariableUniqueID:            "Unique ID of the transaction.",
+	parse.VariableUser:                "Persistent collection initialized by `setuid`.",
+	parse.VariableXML:                 "XML request body, selected by XPath expressions.",
+}
+
+// returns the documentation keyed by the name, case insensitive
+func lookupDoc(docs map[string]string, name string) (string, bool) {
+	for key, doc := range docs {
+		if strings.EqualFold(key, name) {
+			return doc, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,88 @@
+package lsp
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// represents a document opened by the client
+type document struct {
+	// URI of the document, ex. "file:///etc/coraza/rules.conf"
+	uri string
+
+	// current text of the document
+	text string
+}
+
+// returns the path of the document on disk, empty if
+// the URI does not use the file scheme
+func (d *document) path() string {
+	return uriPath(d.uri)
+}
+
+// returns the path the file URI points at, empty if
+// the URI does not use the file scheme
+func uriPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return ""
+	}
+
+	return filepath.FromSlash(parsed.Path)
+}
+
+// returns the file URI pointing at the path
+func pathURI(path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// returns the position of the byte offset within the text
+func positionOf(text string, offset int) Position {
+	offset = min(max(offset, 0), len(text))
+
+	line := strings.Count(text[:offset], "\n")
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+
+	character := 0
+	for _, r := range text[lineStart:offset] {
+		character += len(utf16.Encode([]rune{r}))
+	}
+
+	return Position{Line: line, Character: character}
+}
+
+// returns the byte offset of the position within the text,
+// clamped to the end of the line or text
+func offsetOf(text string, position Position) int {
+	offset := 0
+
+	for range position.Line {
+		next := strings.IndexByte(text[offset:], '\n')
+		if next == -1 {
+			return len(text)
+		}
+
+		offset += next + 1
+	}
+
+	for character := 0; character < position.Character && offset < len(text) && text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+
+		character += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+
+	return offset
+}
+
+// returns the range of the text between both byte offsets
+func rangeOf(text string, start, end int) Range {
+	return Range{Start: positionOf(text, start), End: positionOf(text, end)}
+}
@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPositionOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		offset int
+		want   Position
+	}{
+		{
+			name:   "POSITIVE - start of text",
+			text:   "SecRuleEngine On\n",
+			offset: 0,
+			want:   Position{Line: 0, Character: 0},
+		},
+		{
+			name:   "POSITIVE - second line",
+			text:   "SecRuleEngine On\nSecMarker END",
+			offset: 27,
+			want:   Position{Line: 1, Character: 10},
+		},
+		{
+			name:   "POSITIVE - characters counted in UTF-16 units",
+			text:   "# é😀\nSecMarker END",
+			offset: 8,
+			want:   Position{Line: 0, Character: 5},
+		},
+		{
+			name:   "NEGATIVE - offset past the end is clamped",
+			text:   "SecMarker END",
+			offset: 100,
+			want:   Position{Line: 0, Character: 13},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := positionOf(tt.text, tt.offset)
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOffsetOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		position Position
+		want     int
+	}{
+		{
+			name:     "POSITIVE - second line",
+			text:     "SecRuleEngine On\nSecMarker END",
+			position: Position{Line: 1, Character: 10},
+			want:     27,
+		},
+		{
+			name:     "POSITIVE - characters counted in UTF-16 units",
+			text:     "# é😀\nSecMarker END",
+			position: Position{Line: 0, Character: 5},
+			want:     8,
+		},
+		{
+			name:     "NEGATIVE - character past the end of the line is clamped",
+			text:     "SecRuleEngine On\nSecMarker END",
+			position: Position{Line: 0, Character: 100},
+			want:     16,
+		},
+		{
+			name:     "NEGATIVE - line past the end of the text is clamped",
+			text:     "SecMarker END",
+			position: Position{Line: 3, Character: 0},
+			want:     13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := offsetOf(tt.text, tt.position)
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// kinds of symbols with documentation
+const (
+	symbolDirective = "directive"
+	symbolVariable  = "variable"
+	symbolOperator  = "operator"
+	symbolAction    = "action"
+)
+
+// represents a documented name found within a directive
+type symbol struct {
+	// one of the symbol kinds
+	kind string
+
+	// name of the symbol as written, ex. "REQUEST_HEADERS"
+	name string
+
+	// offsets of the name within the entire file, end exclusive
+	offset, end int
+}
+
+// returns the documentation of the symbol, empty if it is unknown
+func (s *symbol) doc() string {
+	var (
+		doc   string
+		found bool
+	)
+
+	switch s.kind {
+	case symbolDirective:
+		doc, found = lookupDoc(directiveDocs, s.name)
+	case symbolVariable:
+		doc, found = lookupDoc(variableDocs, s.name)
+		if !found {
+			doc, found = fmt.Sprintf("Variable `%s` supported by Coraza.", s.name), true
+		}
+	case symbolOperator:
+		doc, found = lookupDoc(operatorDocs, s.name)
+	case symbolAction:
+		doc, found = lookupDoc(actionDocs, s.name)
+	}
+
+	if !found {
+		return ""
+	}
+
+	return doc
+}
+
+// returns the directive containing the offset, nil if there is none
+func directiveAt(file *parse.File, offset int) *parse.Directive {
+	for _, directive := range file.Directives {
+		if within(offset, directive.Offset, directive.Offset+directive.Len()) {
+			return directive
+		}
+	}
+
+	return nil
+}
+
+// returns the documented symbol at the offset, nil if there is none
+func symbolAt(file *parse.File, offset int) *symbol {
+	directive := directiveAt(file, offset)
+	if directive == nil {
+		return nil
+	}
+
+	if within(offset, directive.Offset, directive.Offset+len(directive.Lexeme)) {
+		return &symbol{
+			kind:   symbolDirective,
+			name:   directive.Lexeme,
+			offset: directive.Offset,
+			end:    directive.Offset + len(directive.Lexeme),
+		}
+	}
+
+	if strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule) && len(directive.Options) > 1 {
+		// variables and operators with errors are reported as diagnostics
+		variables, _ := parse.ParseVariables(file.Contents(), directive.Options[0])
+
+		for _, variable := range variables {
+			if within(offset, variable.Offset, variable.Offset+variable.Len()) {
+				return &symbol{
+					kind:   symbolVariable,
+					name:   variable.Name,
+					offset: variable.Offset,
+					end:    variable.Offset + variable.Len(),
+				}
+			}
+		}
+
+		operator, _ := parse.ParseOperator(file.Contents(), directive.Options[1])
+		if operator != nil && !operator.Implicit &&
+			within(offset, operator.Offset, operator.ArgumentOffset) {
+			return &symbol{
+				kind:   symbolOperator,
+				name:   operator.Name,
+				offset: operator.Offset,
+				end:    operator.ArgumentOffset,
+			}
+		}
+	}
+
+	for _, action := range actionsAt(file, directive) {
+		if within(offset, action.Offset, action.Offset+action.Len()) {
+			return &symbol{
+				kind:   symbolAction,
+				name:   action.Name,
+				offset: action.Offset,
+				end:    action.Offset + len(action.Name),
+			}
+		}
+	}
+
+	return nil
+}
+
+// returns the actions declared by the directive, empty if
+// the directive does not hold actions or they do not parse
+func actionsAt(file *parse.File, directive *parse.Directive) []*parse.Action {
+	var option *parse.Option
+
+	switch {
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecRule):
+		if len(directive.Options) >= 3 {
+			option = directive.Options[2]
+		}
+	case strings.EqualFold(directive.Lexeme, parse.DirectiveSecAction),
+		strings.EqualFold(directive.Lexeme, parse.DirectiveSecDefaultAction):
+		if len(directive.Options) >= 1 {
+			option = directive.Options[0]
+		}
+	}
+
+	if option == nil {
+		return nil
+	}
+
+	actions, _ := parse.ParseActions(file.Contents(), option)
+
+	return actions
+}
+
+// reports whether the offset is within start and end, inclusive
+// so the cursor placed right after a name still points at it
+func within(offset, start, end int) bool {
+	return offset >= start && offset <= end
+}
+
+// responds with the documentation of the symbol under the cursor
+func (s *Server) hover(params json.RawMessage) (any, error) {
+	doc, offset, err := s.documentAt(params)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := parse.Parse([]byte(doc.text))
+	if err != nil {
+		// the parse error is already published as a diagnostic
+		return nil, nil
+	}
+
+	found := symbolAt(file, offset)
+	if found == nil || found.doc() == "" {
+		return nil, nil
+	}
+
+	symbolRange := rangeOf(doc.text, found.offset, found.end)
+
+	return hover{
+		Contents: markupContent{
+			Kind:  "markdown",
+			Value: fmt.Sprintf("**%s** (%s)\n\n%s", found.name, found.kind, found.doc()),
+		},
+		Range: &symbolRange,
+	}, nil
+}
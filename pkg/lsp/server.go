@@ -0,0 +1,267 @@
+// Package lsp implements a language server for SecLang files,
+// speaking the Language Server Protocol over a stream.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// returned by Serve when the client exits without shutting down
+var ErrExitWithoutShutdown = errors.New("client exited without shutting the server down")
+
+// represents a language server connected to a single client
+type Server struct {
+	reader *bufio.Reader
+	writer io.Writer
+
+	// documents opened by the client, keyed by URI
+	documents map[string]*document
+
+	// files next to the opened documents, parsed from disk
+	siblings *siblingCache
+
+	// reports whether the client initialized the server
+	initialized bool
+
+	// reports whether the client requested a shutdown
+	shutdown bool
+}
+
+// handles a request and returns its result
+type handler func(s *Server, params json.RawMessage) (any, error)
+
+// request handlers, keyed by method
+var handlers = map[string]handler{
+	"initialize":              (*Server).initialize,
+	"shutdown":                (*Server).handleShutdown,
+	"textDocument/hover":      (*Server).hover,
+	"textDocument/completion": (*Server).completion,
+	"textDocument/definition": (*Server).definition,
+}
+
+// handles a notification
+type notificationHandler func(s *Server, params json.RawMessage) error
+
+// notification handlers, keyed by method
+var notificationHandlers = map[string]notificationHandler{
+	"textDocument/didOpen":   (*Server).didOpen,
+	"textDocument/didChange": (*Server).didChange,
+	"textDocument/didClose":  (*Server).didClose,
+	"textDocument/didSave":   (*Server).didSave,
+
+	"workspace/didChangeWatchedFiles": (*Server).didChangeWatchedFiles,
+}
+
+// Creates a server reading messages from the reader
+// and writing messages to the writer
+func NewServer(reader io.Reader, writer io.Writer) *Server {
+	return &Server{
+		reader:    bufio.NewReader(reader),
+		writer:    writer,
+		documents: map[string]*document{},
+		siblings:  newSiblingCache(),
+	}
+}
+
+// Serves the client until it sends the exit notification or closes
+// the stream. Returns ErrExitWithoutShutdown if the client exits
+// without requesting a shutdown first.
+func (s *Server) Serve() error {
+	for {
+		body, err := readMessage(s.reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var message request
+		if err := json.Unmarshal(body, &message); err != nil {
+			if err := s.respondError(nil, codeParseError, fmt.Sprintf("invalid message: %v", err)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if message.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+
+			return nil
+		}
+
+		if err := s.handle(&message); err != nil {
+			return err
+		}
+	}
+}
+
+// handles a single request or notification
+func (s *Server) handle(message *request) error {
+	if message.ID == nil {
+		notify, ok := notificationHandlers[message.Method]
+		if !ok || !s.initialized {
+			// unknown notifications are ignored
+			return nil
+		}
+
+		return notify(s, message.Params)
+	}
+
+	handle, ok := handlers[message.Method]
+
+	switch {
+	case !ok:
+		return s.respondError(message.ID, codeMethodNotFound, fmt.Sprintf("method %q is not supported", message.Method))
+	case !s.initialized && message.Method != "initialize":
+		return s.respondError(message.ID, codeServerNotInitialized, "server is not initialized")
+	}
+
+	result, err := handle(s, message.Params)
+	if err != nil {
+		return s.respondError(message.ID, codeInvalidParams, err.Error())
+	}
+
+	return writeMessage(s.writer, response{JSONRPC: "2.0", ID: message.ID, Result: result})
+}
+
+// responds to the request with the given error
+func (s *Server) respondError(id *json.RawMessage, code int, message string) error {
+	return writeMessage(s.writer, errorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   responseError{Code: code, Message: message},
+	})
+}
+
+// sends a notification to the client
+func (s *Server) notify(method string, params any) error {
+	return writeMessage(s.writer, notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// responds with the capabilities of the server
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	s.initialized = true
+
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      true,
+			},
+			HoverProvider:      true,
+			CompletionProvider: completionOptions{},
+			DefinitionProvider: true,
+		},
+		ServerInfo: serverInfo{Name: "seclang-linter"},
+	}, nil
+}
+
+// acknowledges the shutdown, the client exits afterwards
+func (s *Server) handleShutdown(params json.RawMessage) (any, error) {
+	s.shutdown = true
+
+	return nil, nil
+}
+
+// tracks the opened document and publishes its findings
+func (s *Server) didOpen(params json.RawMessage) error {
+	var opened didOpenParams
+	if err := json.Unmarshal(params, &opened); err != nil {
+		return nil
+	}
+
+	doc := &document{uri: opened.TextDocument.URI, text: opened.TextDocument.Text}
+	s.documents[doc.uri] = doc
+
+	return s.publishDiagnostics(doc)
+}
+
+// updates the document and publishes its findings
+func (s *Server) didChange(params json.RawMessage) error {
+	var changed didChangeParams
+	if err := json.Unmarshal(params, &changed); err != nil || len(changed.ContentChanges) == 0 {
+		return nil
+	}
+
+	doc, ok := s.documents[changed.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+
+	// with full synchronization the last change holds the entire text
+	doc.text = changed.ContentChanges[len(changed.ContentChanges)-1].Text
+
+	return s.publishDiagnostics(doc)
+}
+
+// stops tracking the closed document
+func (s *Server) didClose(params json.RawMessage) error {
+	var closed didCloseParams
+	if err := json.Unmarshal(params, &closed); err != nil {
+		return nil
+	}
+
+	delete(s.documents, closed.TextDocument.URI)
+
+	// clear the diagnostics of the closed document
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         closed.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+// forgets the saved file, so the documents next to it see its new contents
+func (s *Server) didSave(params json.RawMessage) error {
+	var saved didSaveParams
+	if err := json.Unmarshal(params, &saved); err != nil {
+		return nil
+	}
+
+	if path := uriPath(saved.TextDocument.URI); path != "" {
+		s.siblings.invalidate(path)
+	}
+
+	return nil
+}
+
+// forgets the files which changed on disk, such as
+// files edited outside of the client or deleted
+func (s *Server) didChangeWatchedFiles(params json.RawMessage) error {
+	var changed didChangeWatchedFilesParams
+	if err := json.Unmarshal(params, &changed); err != nil {
+		return nil
+	}
+
+	for _, change := range changed.Changes {
+		if path := uriPath(change.URI); path != "" {
+			s.siblings.invalidate(path)
+		}
+	}
+
+	return nil
+}
+
+// returns the open document the request points at
+func (s *Server) documentAt(params json.RawMessage) (*document, int, error) {
+	var position textDocumentPositionParams
+	if err := json.Unmarshal(params, &position); err != nil {
+		return nil, 0, fmt.Errorf("invalid params: %w", err)
+	}
+
+	doc, ok := s.documents[position.TextDocument.URI]
+	if !ok {
+		return nil, 0, fmt.Errorf("document %q is not open", position.TextDocument.URI)
+	}
+
+	return doc, offsetOf(doc.text, position.Position), nil
+}
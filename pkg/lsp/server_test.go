@@ -0,0 +1,408 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+// document opened by every server test
+const testDocument = `SecRule ARGS "@rx foo" \
+    "id:1,phase:1,deny,skipAfter:END"
+SecAction "id:2,phase:1,pass,nolog,ctl:ruleRemoveById=1"
+SecRuleRemoveById 1 2
+SecMarker END
+SecAction "id:3,phase:1,pass,nolog,skipAfter:LAST"
+secmarker LAST
+secaction "id:4,phase:1,pass,nolog"
+`
+
+// frames the messages as sent by a client
+func clientMessages(t *testing.T, messages ...string) io.Reader {
+	t.Helper()
+
+	var input bytes.Buffer
+
+	for _, message := range messages {
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(message), message)
+	}
+
+	return &input
+}
+
+// decodes the messages sent by the server
+func serverMessages(t *testing.T, output *bytes.Buffer) []any {
+	t.Helper()
+
+	var (
+		reader   = bufio.NewReader(output)
+		messages []any
+	)
+
+	for {
+		body, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return messages
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var message any
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Fatal(err)
+		}
+
+		messages = append(messages, message)
+	}
+}
+
+// decodes the expected JSON value
+func decode(t *testing.T, value string) any {
+	t.Helper()
+
+	var decoded any
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	return decoded
+}
+
+// returns a request at the given position of the test document
+func positionRequest(method string, line, character int) string {
+	return fmt.Sprintf(
+		`{"jsonrpc":"2.0","id":2,"method":%q,"params":{"textDocument":{"uri":"untitled:rules"},"position":{"line":%d,"character":%d}}}`,
+		method,
+		line,
+		character,
+	)
+}
+
+func TestServer(t *testing.T) {
+	opened, err := json.Marshal(testDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+		didOpen    = `{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"untitled:rules","text":` + string(opened) + `}}}`
+		shutdown   = `{"jsonrpc":"2.0","id":3,"method":"shutdown"}`
+		exit       = `{"jsonrpc":"2.0","method":"exit"}`
+	)
+
+	tests := []struct {
+		name    string
+		request string
+		want    string
+	}{
+		{
+			name:    "POSITIVE - hover over a directive",
+			request: positionRequest("textDocument/hover", 3, 4),
+			want: `{"contents":{"kind":"markdown","value":"**SecRuleRemoveById** (directive)\n\nRemoves the rules with the given IDs or ID ranges declared before it."},` +
+				`"range":{"start":{"line":3,"character":0},"end":{"line":3,"character":17}}}`,
+		},
+		{
+			name:    "POSITIVE - hover over a variable",
+			request: positionRequest("textDocument/hover", 0, 9),
+			want: `{"contents":{"kind":"markdown","value":"**ARGS** (variable)\n\nCollection of query string and request body arguments."},` +
+				`"range":{"start":{"line":0,"character":8},"end":{"line":0,"character":12}}}`,
+		},
+		{
+			name:    "POSITIVE - hover over an operator",
+			request: positionRequest("textDocument/hover", 0, 15),
+			want: `{"contents":{"kind":"markdown","value":"**rx** (operator)\n\nMatches the input against the regular expression, the default operator."},` +
+				`"range":{"start":{"line":0,"character":14},"end":{"line":0,"character":18}}}`,
+		},
+		{
+			name:    "POSITIVE - hover over an action",
+			request: positionRequest("textDocument/hover", 1, 15),
+			want: `{"contents":{"kind":"markdown","value":"**phase** (action)\n\nPhase the rule runs in, from 1 to 5. Defaults to 2."},` +
+				`"range":{"start":{"line":1,"character":10},"end":{"line":1,"character":15}}}`,
+		},
+		{
+			name:    "POSITIVE - hover over an action of a lowercase directive",
+			request: positionRequest("textDocument/hover", 7, 30),
+			want: `{"contents":{"kind":"markdown","value":"**nolog** (action)\n\nPrevents the match from being logged."},` +
+				`"range":{"start":{"line":7,"character":29},"end":{"line":7,"character":34}}}`,
+		},
+		{
+			name:    "NEGATIVE - hover outside of symbols",
+			request: positionRequest("textDocument/hover", 0, 20),
+			want:    `null`,
+		},
+		{
+			name:    "POSITIVE - completion of directive names",
+			request: positionRequest("textDocument/completion", 4, 6),
+			want:    `[{"label":"SecMarker","kind":14,"detail":"Declares a marker which ` + "`skipAfter`" + ` actions jump to."}]`,
+		},
+		{
+			name:    "NEGATIVE - no completion within options",
+			request: positionRequest("textDocument/completion", 4, 12),
+			want:    `[]`,
+		},
+		{
+			name:    "POSITIVE - definition of a skipAfter marker",
+			request: positionRequest("textDocument/definition", 1, 34),
+			want:    `[{"uri":"untitled:rules","range":{"start":{"line":4,"character":10},"end":{"line":4,"character":13}}}]`,
+		},
+		{
+			name:    "POSITIVE - definition of a rule ID removed by ctl",
+			request: positionRequest("textDocument/definition", 2, 53),
+			want:    `[{"uri":"untitled:rules","range":{"start":{"line":1,"character":5},"end":{"line":1,"character":9}}}]`,
+		},
+		{
+			name:    "POSITIVE - definition of a rule ID removed by a directive",
+			request: positionRequest("textDocument/definition", 3, 20),
+			want:    `[{"uri":"untitled:rules","range":{"start":{"line":2,"character":11},"end":{"line":2,"character":15}}}]`,
+		},
+		{
+			name:    "POSITIVE - definition of a lowercase marker directive",
+			request: positionRequest("textDocument/definition", 5, 46),
+			want:    `[{"uri":"untitled:rules","range":{"start":{"line":6,"character":10},"end":{"line":6,"character":14}}}]`,
+		},
+		{
+			name:    "NEGATIVE - no definition outside of references",
+			request: positionRequest("textDocument/definition", 0, 2),
+			want:    `null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			server := NewServer(clientMessages(t, initialize, didOpen, tt.request, shutdown, exit), &output)
+			if err := server.Serve(); err != nil {
+				t.Fatal(err)
+			}
+
+			messages := serverMessages(t, &output)
+			if len(messages) != 4 {
+				t.Fatalf("expected 4 messages, got %d: %v", len(messages), messages)
+			}
+
+			want := map[string]any{"jsonrpc": "2.0", "id": float64(2), "result": decode(t, tt.want)}
+
+			if diff := deep.Equal(messages[2], want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestServerCompletionWindowsLineEndings(t *testing.T) {
+	var (
+		initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+		shutdown   = `{"jsonrpc":"2.0","id":3,"method":"shutdown"}`
+		exit       = `{"jsonrpc":"2.0","method":"exit"}`
+	)
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "POSITIVE - completion after a line break",
+			text: "SecMarker END\r\nSecM",
+			want: `[{"label":"SecMarker","kind":14,"detail":"Declares a marker which ` + "`skipAfter`" + ` actions jump to."}]`,
+		},
+		{
+			name: "NEGATIVE - no completion after a line continuation",
+			text: "SecAction \\\r\nSecM",
+			want: `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opened, err := json.Marshal(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var (
+				output bytes.Buffer
+
+				didOpen = `{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"untitled:rules","text":` + string(opened) + `}}}`
+				request = positionRequest("textDocument/completion", 1, 4)
+			)
+
+			server := NewServer(clientMessages(t, initialize, didOpen, request, shutdown, exit), &output)
+			if err := server.Serve(); err != nil {
+				t.Fatal(err)
+			}
+
+			messages := serverMessages(t, &output)
+			if len(messages) != 4 {
+				t.Fatalf("expected 4 messages, got %d: %v", len(messages), messages)
+			}
+
+			want := map[string]any{"jsonrpc": "2.0", "id": float64(2), "result": decode(t, tt.want)}
+
+			if diff := deep.Equal(messages[2], want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	var (
+		initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+		didOpen    = `{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"untitled:rules","text":"SecAction \"id:1,,pass\""}}}`
+		didChange  = `{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"untitled:rules"},"contentChanges":[{"text":"SecAction \"id:1,pass\""}]}}`
+		didClose   = `{"jsonrpc":"2.0","method":"textDocument/didClose","params":{"textDocument":{"uri":"untitled:rules"}}}`
+	)
+
+	var output bytes.Buffer
+
+	if err := NewServer(clientMessages(t, initialize, didOpen, didChange, didClose), &output).Serve(); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := serverMessages(t, &output)
+
+	want := []any{
+		decode(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"untitled:rules","diagnostics":[`+
+			`{"range":{"start":{"line":0,"character":16},"end":{"line":0,"character":17}},"severity":1,"code":"SL1004","source":"seclang-linter","message":"empty action in action list"}]}}`),
+		decode(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"untitled:rules","diagnostics":[]}}`),
+		decode(t, `{"jsonrpc":"2.0","method":"textDocument/publishDiagnostics","params":{"uri":"untitled:rules","diagnostics":[]}}`),
+	}
+
+	if len(messages) < 1 {
+		t.Fatal("expected a response to initialize")
+	}
+
+	if diff := deep.Equal(messages[1:], want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestServerSiblingCache(t *testing.T) {
+	var (
+		dir       = t.TempDir()
+		sibling   = filepath.Join(dir, "a.conf")
+		doc       = &document{uri: pathURI(filepath.Join(dir, "b.conf")), text: "SecRuleRemoveByID 5"}
+		server    = NewServer(&bytes.Buffer{}, io.Discard)
+		unmatched = []string{`SecRuleRemoveByID "5" matches no rule`}
+	)
+
+	write := func(contents string) {
+		if err := os.WriteFile(sibling, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	messages := func() []string {
+		var messages []string
+		for _, diagnostic := range server.diagnose(doc) {
+			messages = append(messages, diagnostic.Message)
+		}
+
+		return messages
+	}
+
+	write(`SecAction "id:5,phase:1,pass,nolog"`)
+
+	if diff := deep.Equal(messages(), []string(nil)); diff != nil {
+		t.Fatal(diff)
+	}
+
+	// the sibling is parsed once, until the client reports it changed
+	write(`SecAction "id:6,phase:1,pass,nolog"`)
+
+	if diff := deep.Equal(messages(), []string(nil)); diff != nil {
+		t.Error(diff)
+	}
+
+	saved, err := json.Marshal(didSaveParams{TextDocument: textDocumentIdentifier{URI: pathURI(sibling)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.didSave(saved); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(messages(), unmatched); diff != nil {
+		t.Error(diff)
+	}
+
+	// deleting the sibling changes the listing of the directory
+	if err := os.Remove(sibling); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := server.didChangeWatchedFiles(json.RawMessage(`{"changes":[{"uri":` + strconv.Quote(pathURI(sibling)) + `,"type":3}]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(messages(), unmatched); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     any
+		wantErr  error
+	}{
+		{
+			name:     "NEGATIVE - request before initialize",
+			messages: []string{`{"jsonrpc":"2.0","id":1,"method":"shutdown"}`},
+			want:     `{"jsonrpc":"2.0","id":1,"error":{"code":-32002,"message":"server is not initialized"}}`,
+		},
+		{
+			name: "NEGATIVE - unsupported method",
+			messages: []string{
+				`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+				`{"jsonrpc":"2.0","id":2,"method":"textDocument/rename","params":{}}`,
+			},
+			want: `{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"method \"textDocument/rename\" is not supported"}}`,
+		},
+		{
+			name: "NEGATIVE - exit without shutdown",
+			messages: []string{
+				`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+				`{"jsonrpc":"2.0","method":"exit"}`,
+			},
+			wantErr: ErrExitWithoutShutdown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			err := NewServer(clientMessages(t, tt.messages...), &output).Serve()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			messages := serverMessages(t, &output)
+
+			if diff := deep.Equal(messages[len(messages)-1], decode(t, tt.want.(string))); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
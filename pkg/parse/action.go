@@ -0,0 +1,286 @@
+package parse
+
+import "strings"
+
+// all action names supported by Coraza
+const (
+	ActionAccuracy   = "accuracy"
+	ActionAllow      = "allow"
+	ActionAuditLog   = "auditlog"
+	ActionBlock      = "block"
+	ActionCapture    = "capture"
+	ActionChain      = "chain"
+	ActionCtl        = "ctl"
+	ActionDeny       = "deny"
+	ActionDrop       = "drop"
+	ActionExec       = "exec"
+	ActionExpireVar  = "expirevar"
+	ActionID         = "id"
+	ActionInitCol    = "initcol"
+	ActionLog        = "log"
+	ActionLogData    = "logdata"
+	ActionMaturity   = "maturity"
+	ActionMsg        = "msg"
+	ActionMultiMatch = "multiMatch"
+	ActionNoAuditLog = "noauditlog"
+	ActionNoLog      = "nolog"
+	ActionPass       = "pass"
+	ActionPhase      = "phase"
+	ActionRedirect   = "redirect"
+	ActionRev        = "rev"
+	ActionSetEnv     = "setenv"
+	ActionSetRSC     = "setrsc"
+	ActionSetSID     = "setsid"
+	ActionSetUID     = "setuid"
+	ActionSetVar     = "setvar"
+	ActionSeverity   = "severity"
+	ActionSkip       = "skip"
+	ActionSkipAfter  = "skipAfter"
+	ActionStatus     = "status"
+	ActionT          = "t"
+	ActionTag        = "tag"
+	ActionVer        = "ver"
+)
+
+// returns all action names supported by Coraza as a string slice.
+func ActionNames() []string {
+	return []string{
+		ActionAccuracy,
+		ActionAllow,
+		ActionAuditLog,
+		ActionBlock,
+		ActionCapture,
+		ActionChain,
+		ActionCtl,
+		ActionDeny,
+		ActionDrop,
+		ActionExec,
+		ActionExpireVar,
+		ActionID,
+		ActionInitCol,
+		ActionLog,
+		ActionLogData,
+		ActionMaturity,
+		ActionMsg,
+		ActionMultiMatch,
+		ActionNoAuditLog,
+		ActionNoLog,
+		ActionPass,
+		ActionPhase,
+		ActionRedirect,
+		ActionRev,
+		ActionSetEnv,
+		ActionSetRSC,
+		ActionSetSID,
+		ActionSetUID,
+		ActionSetVar,
+		ActionSeverity,
+		ActionSkip,
+		ActionSkipAfter,
+		ActionStatus,
+		ActionT,
+		ActionTag,
+		ActionVer,
+	}
+}
+
+// represents a single action within the action list of a
+// SecRule, SecAction or SecDefaultAction directive.
+// Examples:
+//   - deny
+//   - phase:1
+//   - msg:'quoted value'
+type Action struct {
+	// name of the action, ex. "setvar"
+	Name string
+
+	// value of the action without its enclosing single quotes,
+	// empty when the action takes no value
+	Value string
+
+	// raw action text as written in the file
+	Lexeme string
+
+	// offset of the action within the entire file
+	Offset int
+
+	// offset of the value within the entire file
+	ValueOffset int
+}
+
+// Returns the length of the action lexeme
+func (a *Action) Len() int {
+	return len(a.Lexeme)
+}
+
+// reports whether the value is enclosed in single quotes
+func (a *Action) Quoted() bool {
+	i := a.ValueOffset - a.Offset
+
+	return i > 0 && i <= len(a.Lexeme) && a.Lexeme[i-1] == '\''
+}
+
+// parses the comma separated action list held by the given option.
+// Contents represents the entire read content the option came from.
+func ParseActions(contents []byte, option *Option) ([]*Action, error) {
+	body := option.Lexeme
+	offset := option.Offset
+
+	// the action list is usually a quoted option
+	if len(body) >= 2 && body[0] == '"' && body[len(body)-1] == '"' {
+		body = body[1 : len(body)-1]
+		offset++
+	}
+
+	actions := make([]*Action, 0, strings.Count(body, ",")+1)
+
+	inQuote := false
+	start := 0
+
+	for i := 0; i <= len(body); i++ {
+		if i < len(body) {
+			switch {
+			case body[i] == '\\':
+				// escaped characters and line continuations
+				// never end an action
+				i++
+
+				continue
+			case body[i] == '\'':
+				inQuote = !inQuote
+
+				continue
+			case body[i] != ',' || inQuote:
+				continue
+			}
+		}
+
+		if i == len(body) && inQuote {
+			return nil, &LinterError{
+				Kind:       ErrUnterminatedQuote,
+				Message:    "unterminated single quote in action list",
+				ParseLevel: ParseLevelError,
+				Offset:     offset + start,
+				Distance:   i - start,
+				Contents:   string(contents),
+			}
+		}
+
+		action := parseAction(body[start:i], offset+start)
+		if action == nil {
+			linterError := &LinterError{
+				Kind:       ErrEmptyAction,
+				Message:    "empty action in action list",
+				ParseLevel: ParseLevelError,
+				Offset:     offset + i,
+				Distance:   1,
+				Contents:   string(contents),
+			}
+
+			// remove the comma ending the empty action, or the
+			// one before it when the list ends with a comma
+			comma := i
+			if i == len(body) {
+				comma = start - 1
+			}
+
+			if comma >= 0 && body[comma] == ',' {
+				linterError.Fix = &SuggestedFix{
+					Message: "remove the extra comma",
+					Edits:   []TextEdit{{Offset: offset + comma, Distance: 1}},
+				}
+			}
+
+			return nil, linterError
+		}
+
+		actions = append(actions, action)
+
+		start = i + 1
+	}
+
+	return actions, nil
+}
+
+// parses a single raw action found at the given offset,
+// returns nil if the raw action is empty
+func parseAction(raw string, offset int) *Action {
+	start, end := trimContinuation(raw)
+	if start == end {
+		return nil
+	}
+
+	lexeme := raw[start:end]
+	offset += start
+
+	name, value, found := strings.Cut(lexeme, ":")
+
+	action := &Action{
+		Name:        strings.TrimSpace(name),
+		Lexeme:      lexeme,
+		Offset:      offset,
+		ValueOffset: offset + len(lexeme),
+	}
+
+	if !found {
+		return action
+	}
+
+	valueStart, valueEnd := trimContinuation(value)
+	value = value[valueStart:valueEnd]
+	action.ValueOffset = offset + len(name) + 1 + valueStart
+
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		value = value[1 : len(value)-1]
+		action.ValueOffset++
+	}
+
+	action.Value = value
+
+	return action
+}
+
+// returns the start and end index of the given string
+// without surrounding whitespace and line continuations
+func trimContinuation(raw string) (int, int) {
+	start, end := 0, len(raw)
+
+	for start < end {
+		if isSpace(raw[start]) {
+			start++
+
+			continue
+		}
+
+		if length := continuationLen(raw[start:end]); length > 0 {
+			start += length
+
+			continue
+		}
+
+		break
+	}
+
+	for end > start {
+		if length := continuationSuffixLen(raw[start:end]); length > 0 {
+			end -= length
+
+			continue
+		}
+
+		if isSpace(raw[end-1]) {
+			end--
+
+			continue
+		}
+
+		break
+	}
+
+	return start, end
+}
+
+// reports whether the given byte is a whitespace character
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
@@ -0,0 +1,174 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseActions(t *testing.T) {
+	type args struct {
+		contents []byte
+		option   *Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*Action
+		wantErr bool
+	}{
+		{
+			name: "POSITIVE - single action without value",
+			args: args{
+				contents: []byte(`SecAction deny`),
+				option: &Option{
+					Lexeme: "deny",
+					Offset: 10,
+				},
+			},
+			want: []*Action{
+				{
+					Name:        "deny",
+					Lexeme:      "deny",
+					Offset:      10,
+					ValueOffset: 14,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - quoted actions with values",
+			args: args{
+				contents: []byte(`SecAction "id:1,phase:2"`),
+				option: &Option{
+					Lexeme: `"id:1,phase:2"`,
+					Offset: 10,
+				},
+			},
+			want: []*Action{
+				{
+					Name:        "id",
+					Value:       "1",
+					Lexeme:      "id:1",
+					Offset:      11,
+					ValueOffset: 14,
+				},
+				{
+					Name:        "phase",
+					Value:       "2",
+					Lexeme:      "phase:2",
+					Offset:      16,
+					ValueOffset: 22,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - single quoted value containing commas",
+			args: args{
+				contents: []byte(`SecAction "msg:'a, b',pass"`),
+				option: &Option{
+					Lexeme: `"msg:'a, b',pass"`,
+					Offset: 10,
+				},
+			},
+			want: []*Action{
+				{
+					Name:        "msg",
+					Value:       "a, b",
+					Lexeme:      "msg:'a, b'",
+					Offset:      11,
+					ValueOffset: 16,
+				},
+				{
+					Name:        "pass",
+					Lexeme:      "pass",
+					Offset:      22,
+					ValueOffset: 26,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - actions split by line continuations",
+			args: args{
+				contents: []byte("SecAction \\\n    \"id:1,\\\n    pass\""),
+				option: &Option{
+					Lexeme: "\"id:1,\\\n    pass\"",
+					Offset: 16,
+				},
+			},
+			want: []*Action{
+				{
+					Name:        "id",
+					Value:       "1",
+					Lexeme:      "id:1",
+					Offset:      17,
+					ValueOffset: 20,
+				},
+				{
+					Name:        "pass",
+					Lexeme:      "pass",
+					Offset:      28,
+					ValueOffset: 32,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - actions split by windows line continuations",
+			args: args{
+				contents: []byte("SecAction \\\r\n    \"id:1,\\\r\n    pass\""),
+				option: &Option{
+					Lexeme: "\"id:1,\\\r\n    pass\"",
+					Offset: 17,
+				},
+			},
+			want: []*Action{
+				{
+					Name:        "id",
+					Value:       "1",
+					Lexeme:      "id:1",
+					Offset:      18,
+					ValueOffset: 21,
+				},
+				{
+					Name:        "pass",
+					Lexeme:      "pass",
+					Offset:      30,
+					ValueOffset: 34,
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - trailing comma",
+			args: args{
+				contents: []byte(`SecAction "id:1,"`),
+				option: &Option{
+					Lexeme: `"id:1,"`,
+					Offset: 10,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NEGATIVE - unterminated single quote",
+			args: args{
+				contents: []byte(`SecAction "msg:'a"`),
+				option: &Option{
+					Lexeme: `"msg:'a"`,
+					Offset: 10,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseActions(tt.args.contents, tt.args.option)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseActions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
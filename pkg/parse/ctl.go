@@ -0,0 +1,59 @@
+package parse
+
+import "strings"
+
+// represents the value of a ctl action.
+// Examples:
+//   - ruleEngine=Off
+//   - ruleRemoveTargetById=942100;ARGS:password
+type Ctl struct {
+	// name of the changed option, ex. "ruleRemoveTargetById"
+	Option string
+
+	// argument of the option, ex. "942100"
+	Argument string
+
+	// target expression following the argument, ex. "ARGS:password",
+	// empty if the option does not take a target
+	Target string
+
+	// offset of the option within the entire file
+	Offset int
+
+	// offset of the argument within the entire file
+	ArgumentOffset int
+
+	// offset of the target within the entire file
+	TargetOffset int
+}
+
+// parses the value of the given ctl action.
+// Contents represents the entire read content the action came from.
+func ParseCtl(contents []byte, action *Action) (*Ctl, error) {
+	option, argument, found := strings.Cut(action.Value, "=")
+	if !found || option == "" {
+		return nil, &LinterError{
+			Kind:       ErrInvalidCtl,
+			Message:    "expected ctl option and argument separated by '='",
+			ParseLevel: ParseLevelError,
+			Offset:     action.ValueOffset,
+			Distance:   max(len(action.Value), 1),
+			Contents:   string(contents),
+		}
+	}
+
+	ctl := &Ctl{
+		Option:         option,
+		Argument:       argument,
+		Offset:         action.ValueOffset,
+		ArgumentOffset: action.ValueOffset + len(option) + 1,
+	}
+
+	if argument, target, found := strings.Cut(argument, ";"); found {
+		ctl.Argument = argument
+		ctl.Target = target
+		ctl.TargetOffset = ctl.ArgumentOffset + len(argument) + 1
+	}
+
+	return ctl, nil
+}
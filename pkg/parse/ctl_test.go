@@ -0,0 +1,87 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseCtl(t *testing.T) {
+	type args struct {
+		contents []byte
+		action   *Action
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Ctl
+		wantErr bool
+	}{
+		{
+			name: "POSITIVE - option with argument",
+			args: args{
+				contents: []byte(`SecAction "ctl:ruleEngine=Off"`),
+				action: &Action{
+					Name:        "ctl",
+					Value:       "ruleEngine=Off",
+					Lexeme:      "ctl:ruleEngine=Off",
+					Offset:      11,
+					ValueOffset: 15,
+				},
+			},
+			want: &Ctl{
+				Option:         "ruleEngine",
+				Argument:       "Off",
+				Offset:         15,
+				ArgumentOffset: 26,
+			},
+		},
+		{
+			name: "POSITIVE - option with argument and target",
+			args: args{
+				contents: []byte(`SecAction "ctl:ruleRemoveTargetById=942100;ARGS:password"`),
+				action: &Action{
+					Name:        "ctl",
+					Value:       "ruleRemoveTargetById=942100;ARGS:password",
+					Lexeme:      "ctl:ruleRemoveTargetById=942100;ARGS:password",
+					Offset:      11,
+					ValueOffset: 15,
+				},
+			},
+			want: &Ctl{
+				Option:         "ruleRemoveTargetById",
+				Argument:       "942100",
+				Target:         "ARGS:password",
+				Offset:         15,
+				ArgumentOffset: 36,
+				TargetOffset:   43,
+			},
+		},
+		{
+			name: "NEGATIVE - missing argument separator",
+			args: args{
+				contents: []byte(`SecAction "ctl:ruleEngine"`),
+				action: &Action{
+					Name:        "ctl",
+					Value:       "ruleEngine",
+					Lexeme:      "ctl:ruleEngine",
+					Offset:      11,
+					ValueOffset: 15,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCtl(tt.args.contents, tt.args.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCtl() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package parse
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 )
@@ -103,10 +102,7 @@ func ParseDirective(contents []byte, offset int) (*Directive, error) {
 	if matchIndex != nil {
 		options, err := ParseOptions(contents, offset+matchIndex[1])
 		if err != nil {
-			return nil, fmt.Errorf(
-				"could not parse options: %w",
-				err,
-			)
+			return nil, err
 		}
 
 		offsetContent := contents[offset:]
@@ -123,6 +119,7 @@ func ParseDirective(contents []byte, offset int) (*Directive, error) {
 	return nil, &LinterError{
 		Offset:     offset,
 		Distance:   1,
+		Kind:       ErrUnexpectedToken,
 		Message:    "expected alphabetic characters for directive",
 		ParseLevel: ParseLevelError,
 		Contents:   string(contents),
@@ -145,10 +142,7 @@ func ParseDirectives(contents []byte) ([]*Directive, error) {
 		make([]*Directive, 0, lines),
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"problems while traversing directive listing: %w",
-			err,
-		)
+		return nil, err
 	}
 
 	if len(directives) == 0 {
@@ -195,10 +189,7 @@ func parseDirectives(content []byte, offset int, directives []*Directive) ([]*Di
 	if patternDirective.Match(offsetContents) {
 		directive, err := ParseDirective(content, offset)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"could not parse directive: %w",
-				err,
-			)
+			return nil, err
 		}
 
 		return parseDirectives(
@@ -211,6 +202,7 @@ func parseDirectives(content []byte, offset int, directives []*Directive) ([]*Di
 	return nil, &LinterError{
 		Offset:     offset,
 		Distance:   1,
+		Kind:       ErrUnexpectedToken,
 		Message:    "unexpected token while attempting to read directive",
 		ParseLevel: ParseLevelError,
 		Contents:   string(content),
@@ -4,10 +4,26 @@ package parse
 type File struct {
 	// name represents the path of the file
 	name string
+	// contents represents the entire read content of the file
+	contents []byte
 	// list of directives found in the file
 	Directives []*Directive
+	// files first loaded by each Include directive, in load order
+	includes map[*Directive][]*File
 }
 
 func (f *File) Name() string {
 	return f.name
 }
+
+// returns the entire read content of the file
+func (f *File) Contents() []byte {
+	return f.contents
+}
+
+// returns the files first loaded by the given Include directive of the
+// file, in load order. Include directives are only resolved by ParseFS,
+// files parsed otherwise never include other files.
+func (f *File) Included(directive *Directive) []*File {
+	return f.includes[directive]
+}
@@ -0,0 +1,108 @@
+package parse
+
+import (
+	"slices"
+	"strings"
+)
+
+// represents the replacement of a range of the file contents,
+// using the same Offset and Distance model as LinterError
+type TextEdit struct {
+	// start index of the replaced range
+	Offset int
+
+	// distance to the end of the replaced range,
+	// zero to insert the replacement at the offset
+	Distance int
+
+	// text replacing the range
+	Replacement string
+}
+
+// returns offset value of the end of the
+// replaced range, exclusive
+func (e TextEdit) OffsetEnd() int {
+	return e.Offset + e.Distance
+}
+
+// represents a mechanical fix of a linter error,
+// made of edits which are applied together
+type SuggestedFix struct {
+	// description of the fix, ex. "remove the duplicate transformation"
+	Message string
+
+	// edits of the fix, which never overlap each other
+	Edits []TextEdit
+}
+
+// returns the range of the file contents touched by the fix
+func (f *SuggestedFix) span() (int, int) {
+	start, end := -1, -1
+
+	for _, edit := range f.Edits {
+		if start == -1 || edit.Offset < start {
+			start = edit.Offset
+		}
+
+		end = max(end, edit.OffsetEnd())
+	}
+
+	return start, end
+}
+
+// Applies the given fixes to the contents and returns the fixed
+// contents along with the fixes which were applied. Fixes touching
+// the range of an earlier fix are skipped as a whole, so running
+// the linter again applies them to the fixed contents.
+func ApplyFixes(contents []byte, fixes []*SuggestedFix) ([]byte, []*SuggestedFix) {
+	sorted := slices.Clone(fixes)
+
+	slices.SortStableFunc(sorted, func(a, b *SuggestedFix) int {
+		startA, _ := a.span()
+		startB, _ := b.span()
+
+		return startA - startB
+	})
+
+	var (
+		applied []*SuggestedFix
+		edits   []TextEdit
+
+		// range touched by the last applied fix
+		start, end = -1, -1
+	)
+
+	for _, fix := range sorted {
+		fixStart, fixEnd := fix.span()
+
+		// fixes starting at the same offset conflict even when
+		// both insert text, since their order is undefined
+		if fixStart == -1 || fixStart < end || fixStart == start {
+			continue
+		}
+
+		applied = append(applied, fix)
+		edits = append(edits, fix.Edits...)
+		start, end = fixStart, fixEnd
+	}
+
+	slices.SortStableFunc(edits, func(a, b TextEdit) int {
+		return a.Offset - b.Offset
+	})
+
+	var (
+		out      strings.Builder
+		previous = 0
+	)
+
+	for _, edit := range edits {
+		out.Write(contents[previous:edit.Offset])
+		out.WriteString(edit.Replacement)
+
+		previous = edit.OffsetEnd()
+	}
+
+	out.Write(contents[previous:])
+
+	return []byte(out.String()), applied
+}
@@ -0,0 +1,76 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestApplyFixes(t *testing.T) {
+	removeComma := &SuggestedFix{
+		Message: "remove the extra comma",
+		Edits:   []TextEdit{{Offset: 15, Distance: 1}},
+	}
+	insertNone := &SuggestedFix{
+		Message: "insert t:none",
+		Edits:   []TextEdit{{Offset: 11, Replacement: "t:none,"}},
+	}
+	removeAction := &SuggestedFix{
+		Message: "remove the action",
+		Edits:   []TextEdit{{Offset: 11, Distance: 5}},
+	}
+	quoteValue := &SuggestedFix{
+		Message: "quote the value",
+		Edits: []TextEdit{
+			{Offset: 21, Replacement: "'"},
+			{Offset: 24, Replacement: "'"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		contents    string
+		fixes       []*SuggestedFix
+		want        string
+		wantApplied []*SuggestedFix
+	}{
+		{
+			name:        "POSITIVE - no fixes",
+			contents:    `SecAction "id:1"`,
+			want:        `SecAction "id:1"`,
+			wantApplied: nil,
+		},
+		{
+			name:        "POSITIVE - fixes applied in offset order",
+			contents:    `SecAction "id:1,,msg:foo"`,
+			fixes:       []*SuggestedFix{quoteValue, removeComma, insertNone},
+			want:        `SecAction "t:none,id:1,msg:'foo'"`,
+			wantApplied: []*SuggestedFix{insertNone, removeComma, quoteValue},
+		},
+		{
+			name:        "NEGATIVE - overlapping fix is skipped",
+			contents:    `SecAction "id:1,,msg:foo"`,
+			fixes:       []*SuggestedFix{removeAction, removeComma},
+			want:        `SecAction ",msg:foo"`,
+			wantApplied: []*SuggestedFix{removeAction},
+		},
+		{
+			name:        "NEGATIVE - fix starting at the same offset is skipped",
+			contents:    `SecAction "id:1,,msg:foo"`,
+			fixes:       []*SuggestedFix{insertNone, removeAction},
+			want:        `SecAction "t:none,id:1,,msg:foo"`,
+			wantApplied: []*SuggestedFix{insertNone},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied := ApplyFixes([]byte(tt.contents), tt.fixes)
+			if diff := deep.Equal(string(got), tt.want); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(applied, tt.wantApplied); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package parse
+
+import (
+	"strconv"
+	"strings"
+)
+
+// represents an inclusive range of rule IDs
+type IDRange struct {
+	Start int
+	End   int
+}
+
+// reports whether the ID falls within the range
+func (r IDRange) Contains(id int) bool {
+	return r.Start <= id && id <= r.End
+}
+
+// parses a rule ID or an ID range, ex. "942100-942999"
+func ParseIDRange(value string) (IDRange, bool) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+
+	start, err := strconv.Atoi(startValue)
+	if err != nil || start < 0 {
+		return IDRange{}, false
+	}
+
+	if !isRange {
+		return IDRange{Start: start, End: start}, true
+	}
+
+	end, err := strconv.Atoi(endValue)
+	if err != nil || end < start {
+		return IDRange{}, false
+	}
+
+	return IDRange{Start: start, End: end}, true
+}
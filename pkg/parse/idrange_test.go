@@ -0,0 +1,49 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseIDRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   IDRange
+		wantOk bool
+	}{
+		{
+			name:   "POSITIVE - single ID",
+			value:  "942100",
+			want:   IDRange{Start: 942100, End: 942100},
+			wantOk: true,
+		},
+		{
+			name:   "POSITIVE - ID range",
+			value:  "942100-942199",
+			want:   IDRange{Start: 942100, End: 942199},
+			wantOk: true,
+		},
+		{
+			name:  "NEGATIVE - not a number",
+			value: "942100a",
+		},
+		{
+			name:  "NEGATIVE - range ending before its start",
+			value: "942199-942100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseIDRange(tt.value)
+			if ok != tt.wantOk {
+				t.Fatalf("ParseIDRange() ok = %v, want %v", ok, tt.wantOk)
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
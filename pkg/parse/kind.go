@@ -0,0 +1,34 @@
+package parse
+
+// identifies what went wrong in a linter error with a stable
+// code, matched against linter errors with errors.Is
+type Kind struct {
+	// stable code of the kind, ex. "SL1001"
+	Code string
+
+	// short description of the kind, ex. "unterminated quote"
+	Name string
+}
+
+// Implements error interface, so kinds can be the target of errors.Is
+func (k *Kind) Error() string {
+	return k.Code + " " + k.Name
+}
+
+// kinds of errors found while parsing
+var (
+	ErrUnterminatedQuote = &Kind{Code: "SL1001", Name: "unterminated quote"}
+	ErrUnexpectedToken   = &Kind{Code: "SL1002", Name: "unexpected token"}
+	ErrMissingOptions    = &Kind{Code: "SL1003", Name: "missing directive options"}
+	ErrEmptyAction       = &Kind{Code: "SL1004", Name: "empty action"}
+	ErrInvalidCtl        = &Kind{Code: "SL1005", Name: "invalid ctl action"}
+	ErrInvalidMacro      = &Kind{Code: "SL1006", Name: "invalid macro"}
+	ErrInvalidOperator   = &Kind{Code: "SL1007", Name: "invalid operator"}
+	ErrInvalidSetvar     = &Kind{Code: "SL1008", Name: "invalid setvar action"}
+	ErrInvalidVariable   = &Kind{Code: "SL1009", Name: "invalid variable"}
+	ErrInclude           = &Kind{Code: "SL1010", Name: "unresolved Include"}
+
+	// a backslash followed by spaces or tabs at the end of a line,
+	// which does not continue the directive on the next line
+	ErrContinuationWhitespace = &Kind{Code: "SL1011", Name: "whitespace after line continuation"}
+)
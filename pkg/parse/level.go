@@ -0,0 +1,76 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// names and ANSI colors of the parse levels,
+// from the most to the least severe
+var levelNames = []struct {
+	level int
+	name  string
+	color string
+}{
+	{ParseLevelError, "error", ansiRed},
+	{ParseLevelWarning, "warning", ansiYellow},
+	{ParseLevelInfo, "info", ansiBlue},
+	{ParseLevelHint, "hint", ansiCyan},
+}
+
+// returns the rank of the level, lower is more severe
+func levelRank(level int) int {
+	for rank, named := range levelNames {
+		if named.level == level {
+			return rank
+		}
+	}
+
+	return len(levelNames)
+}
+
+// reports whether the level is as severe as the threshold or more
+func LevelAtLeast(level, threshold int) bool {
+	return levelRank(level) <= levelRank(threshold)
+}
+
+// returns the name of the level, ex. "warning"
+func LevelName(level int) string {
+	rank := levelRank(level)
+	if rank == len(levelNames) {
+		return fmt.Sprintf("level %d", level)
+	}
+
+	return levelNames[rank].name
+}
+
+// returns the levels from the most to the least severe
+func Levels() []int {
+	levels := make([]int, 0, len(levelNames))
+	for _, named := range levelNames {
+		levels = append(levels, named.level)
+	}
+
+	return levels
+}
+
+// returns the text in the ANSI color of the level
+func ColorLevel(level int, text string) string {
+	rank := levelRank(level)
+	if rank == len(levelNames) {
+		return text
+	}
+
+	return levelNames[rank].color + text + ansiReset
+}
+
+// returns the level with the given name, case insensitive
+func ParseLevelNamed(name string) (int, error) {
+	for _, named := range levelNames {
+		if strings.EqualFold(named.name, name) {
+			return named.level, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown severity %q, expected one of: error, warning, info, hint", name)
+}
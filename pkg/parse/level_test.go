@@ -0,0 +1,98 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLevelAtLeast(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     int
+		threshold int
+		want      bool
+	}{
+		{
+			name:      "POSITIVE - error reaches a warning threshold",
+			level:     ParseLevelError,
+			threshold: ParseLevelWarning,
+			want:      true,
+		},
+		{
+			name:      "POSITIVE - level reaches its own threshold",
+			level:     ParseLevelInfo,
+			threshold: ParseLevelInfo,
+			want:      true,
+		},
+		{
+			name:      "POSITIVE - warning reaches a hint threshold",
+			level:     ParseLevelWarning,
+			threshold: ParseLevelHint,
+			want:      true,
+		},
+		{
+			name:      "NEGATIVE - warning is below an error threshold",
+			level:     ParseLevelWarning,
+			threshold: ParseLevelError,
+			want:      false,
+		},
+		{
+			name:      "NEGATIVE - hint is below an info threshold",
+			level:     ParseLevelHint,
+			threshold: ParseLevelInfo,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LevelAtLeast(tt.level, tt.threshold); got != tt.want {
+				t.Errorf("LevelAtLeast() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLevelNamed(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:  "POSITIVE - error",
+			level: "error",
+			want:  ParseLevelError,
+		},
+		{
+			name:  "POSITIVE - case insensitive",
+			level: "Hint",
+			want:  ParseLevelHint,
+		},
+		{
+			name:    "NEGATIVE - unknown name",
+			level:   "fatal",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevelNamed(tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevelNamed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+
+			if err == nil {
+				if diff := deep.Equal(LevelName(got), strings.ToLower(tt.level)); diff != nil {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
@@ -2,16 +2,29 @@ package parse
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 )
 
+// severities of linter errors, see LevelAtLeast for their order
 const (
+	// a likely mistake which does not stop Coraza from loading the rules
 	ParseLevelWarning = iota
+	// a mistake Coraza rejects or which breaks the rule
 	ParseLevelError
+	// a remark about rules which are valid but could be clearer
+	ParseLevelInfo
+	// a suggestion of a small cleanup
+	ParseLevelHint
 )
 
 type LinterError struct {
+	// name of the file containing the error,
+	// empty when the file is unknown
+	File string
+
+	// kind of the error, nil if the error has no kind
+	Kind *Kind
+
 	// error message
 	Message string
 
@@ -26,6 +39,10 @@ type LinterError struct {
 
 	// entire content
 	Contents string
+
+	// mechanical fix of the error,
+	// nil if the error has no fix
+	Fix *SuggestedFix
 }
 
 // returns offset value of the
@@ -34,114 +51,117 @@ func (e *LinterError) OffsetEnd() int {
 	return e.Offset + e.Distance
 }
 
-// Implements error interface
-func (e *LinterError) Error() string {
-	var builder strings.Builder
+// returns the stable code of the kind of the
+// error, empty if the error has no kind
+func (e *LinterError) Code() string {
+	if e.Kind == nil {
+		return ""
+	}
 
-	// start on a new line
-	builder.WriteRune('\n')
+	return e.Kind.Code
+}
 
-	switch e.ParseLevel {
-	case ParseLevelError:
-		builder.WriteString("Error: ")
-	case ParseLevelWarning:
-		builder.WriteString("Warning: ")
-	}
+// reports whether the target is the kind of the error,
+// so errors.Is(err, ErrUnterminatedQuote) finds it
+func (e *LinterError) Is(target error) bool {
+	kind, ok := target.(*Kind)
 
-	builder.WriteString(e.Message)
-	builder.WriteRune('\n')
+	return ok && e.Kind != nil && kind == e.Kind
+}
 
-	patternNewline := regexp.MustCompile(`\n`)
-	leftNewlines := patternNewline.FindAllStringIndex(e.Contents[:e.Offset], -1)
+// returns the linter errors joined or wrapped into the given error,
+// in the order they were joined
+func LinterErrors(err error) []*LinterError {
+	switch e := err.(type) {
+	case *LinterError:
+		return []*LinterError{e}
+	case interface{ Unwrap() []error }:
+		var found []*LinterError
+		for _, wrapped := range e.Unwrap() {
+			found = append(found, LinterErrors(wrapped)...)
+		}
 
-	column := e.Offset
-	if len(leftNewlines) > 0 {
-		column = e.Offset - leftNewlines[len(leftNewlines)-1][1]
+		return found
+	case interface{ Unwrap() error }:
+		return LinterErrors(e.Unwrap())
 	}
 
-	builder.WriteString(
-		fmt.Sprintf(
-			"line %d, column %d:\n",
-			len(leftNewlines)+1,
-			column,
-		),
-	)
+	return nil
+}
 
-	builder.WriteString(e.underlined())
+// Returns the line of the start of the error, counted from 1,
+// and its column in bytes, counted from 0
+func (e *LinterError) Position() (int, int) {
+	return position(e.Contents, e.Offset)
+}
 
-	return builder.String()
+// Returns the line of the last byte of the error, counted from 1,
+// and its column in bytes, counted from 0. Errors without a
+// distance end where they start.
+func (e *LinterError) EndPosition() (int, int) {
+	end := min(e.OffsetEnd(), len(e.Contents))
+
+	return position(e.Contents, max(end-1, e.Offset))
 }
 
-// returns the error lines underlined with carrots
-func (e *LinterError) underlined() string {
-	var (
-		builder              strings.Builder
-		patternNewline       = regexp.MustCompile(`\n`)
-		patternNotWhiteSpace = regexp.MustCompile(`\S`)
-	)
-
-	leftNewlines := patternNewline.FindAllStringIndex(e.Contents[:e.Offset], -1)
-	innerNewlines := patternNewline.FindAllStringIndex(e.Contents[e.Offset:e.OffsetEnd()], -1)
-	lastNewlines := patternNewline.FindAllStringIndex(e.Contents, len(leftNewlines)+len(innerNewlines)+1)
-
-	lineStartOffset := 0
-	if len(leftNewlines) != 0 {
-		lineStartOffset = leftNewlines[len(leftNewlines)-1][1]
-	}
+// returns the line of the offset within the contents, counted
+// from 1, and its column in bytes, counted from 0
+func position(contents string, offset int) (int, int) {
+	offset = min(offset, len(contents))
 
-	lineEndOffset := 0
-	if len(lastNewlines) == len(leftNewlines)+len(innerNewlines) {
-		lineEndOffset = len(e.Contents)
-	} else {
-		lineEndOffset = lastNewlines[len(lastNewlines)-1][0]
-	}
+	line := strings.Count(contents[:offset], "\n") + 1
+	column := offset - (strings.LastIndexByte(contents[:offset], '\n') + 1)
 
-	spaces := patternNotWhiteSpace.ReplaceAllString(e.Contents[lineStartOffset:e.Offset], " ")
-	carrots := patternNotWhiteSpace.ReplaceAllString(e.Contents[e.Offset:e.OffsetEnd()], "^")
+	return line, column
+}
 
-	underlineLines := strings.Split(spaces+carrots, "\n")
-	contentLines := strings.Split(e.Contents[lineStartOffset:lineEndOffset], "\n")
+// Implements error interface, rendering the error with the default options
+func (e *LinterError) Error() string {
+	return e.Render(RenderOptions{})
+}
 
-	for i := range len(contentLines) {
-		if len(contentLines[i]) <= 80 {
-			builder.WriteString(contentLines[i] + "\n")
-			builder.WriteString(underlineLines[i] + "\n")
+// Renders the error with its level, code, message and position,
+// followed by the lines of the error underlined with carets
+func (e *LinterError) Render(options RenderOptions) string {
+	var builder strings.Builder
 
-			continue
-		}
+	// start on a new line
+	builder.WriteRune('\n')
 
-		splitContentLine := splitLongLine(contentLines[i])
-		splitUnderlineLine := splitLongLine(underlineLines[i])
+	label := ""
 
-		for j := range len(splitContentLine) {
-			builder.WriteString(splitContentLine[j] + "\n")
-			builder.WriteString(splitUnderlineLine[j] + "\n")
-		}
+	switch e.ParseLevel {
+	case ParseLevelError:
+		label = "Error"
+	case ParseLevelWarning:
+		label = "Warning"
+	case ParseLevelInfo:
+		label = "Info"
+	case ParseLevelHint:
+		label = "Hint"
 	}
 
-	return builder.String()
-}
+	if e.Kind != nil {
+		label += "[" + e.Kind.Code + "]"
+	}
 
-// splits line with newline character and some spaces
-// if the line is long
-func splitLongLine(line string) []string {
-	lines := make([]string, 0, len(line)/80)
+	if options.Color {
+		builder.WriteString(ansiBold + ColorLevel(e.ParseLevel, label) + ansiBold + ": " + e.Message + ansiReset)
+	} else {
+		builder.WriteString(label + ": " + e.Message)
+	}
 
-	for i := 0; i < len(line); i += 80 {
-		prefix := ""
+	builder.WriteRune('\n')
 
-		if i != 0 {
-			prefix = "    "
-		}
+	if e.File != "" {
+		builder.WriteString(e.File + ", ")
+	}
 
-		if i+80 > len(line) {
-			lines = append(lines, prefix+line[i:])
+	line, column := e.Position()
 
-			continue
-		}
+	builder.WriteString(fmt.Sprintf("line %d, column %d:\n", line, column))
 
-		lines = append(lines, prefix+line[i:i+80])
-	}
+	builder.WriteString(e.underlined(options))
 
-	return lines
+	return builder.String()
 }
@@ -1,8 +1,12 @@
 package parse
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/go-test/deep"
 )
 
 // constructs a message separated by newline
@@ -12,6 +16,8 @@ func joinString(messages ...string) string {
 
 func TestLinterError_Error(t *testing.T) {
 	type fields struct {
+		File       string
+		Kind       *Kind
 		Offset     int
 		Distance   int
 		Message    string
@@ -42,7 +48,99 @@ func TestLinterError_Error(t *testing.T) {
 			),
 		},
 		{
-			name: "POSITIVE - single column error with tab start",
+			name: "POSITIVE - error with a code",
+			fields: fields{
+				Kind:       ErrUnterminatedQuote,
+				Offset:     8,
+				Distance:   7,
+				Message:    "unterminated single quote in action list",
+				ParseLevel: ParseLevelError,
+				Content:    "SecRule optionA optionB",
+			},
+			want: joinString(
+				"",
+				"Error[SL1001]: unterminated single quote in action list",
+				"line 1, column 8:",
+				"SecRule optionA optionB",
+				"        ^^^^^^^",
+				"",
+			),
+		},
+		{
+			name: "POSITIVE - single column error with file name",
+			fields: fields{
+				File:       "rules.conf",
+				Offset:     8,
+				Distance:   7,
+				Message:    "This column is wrong",
+				ParseLevel: ParseLevelWarning,
+				Content:    "SecRule optionA optionB",
+			},
+			want: joinString(
+				"",
+				"Warning: This column is wrong",
+				"rules.conf, line 1, column 8:",
+				"SecRule optionA optionB",
+				"        ^^^^^^^",
+				"",
+			),
+		},
+		{
+			name: "POSITIVE - info level",
+			fields: fields{
+				Offset:     8,
+				Distance:   7,
+				Message:    "This column is unused",
+				ParseLevel: ParseLevelInfo,
+				Content:    "SecRule optionA optionB",
+			},
+			want: joinString(
+				"",
+				"Info: This column is unused",
+				"line 1, column 8:",
+				"SecRule optionA optionB",
+				"        ^^^^^^^",
+				"",
+			),
+		},
+		{
+			name: "POSITIVE - hint level",
+			fields: fields{
+				Offset:     16,
+				Distance:   7,
+				Message:    "This column could be shorter",
+				ParseLevel: ParseLevelHint,
+				Content:    "SecRule optionA optionB",
+			},
+			want: joinString(
+				"",
+				"Hint: This column could be shorter",
+				"line 1, column 16:",
+				"SecRule optionA optionB",
+				"                ^^^^^^^",
+				"",
+			),
+		},
+		{
+			name: "POSITIVE - whitespace only error",
+			fields: fields{
+				Offset:     9,
+				Distance:   2,
+				Message:    "This whitespace is wrong",
+				ParseLevel: ParseLevelError,
+				Content:    "SecRule \\  \n  optionA",
+			},
+			want: joinString(
+				"",
+				"Error: This whitespace is wrong",
+				"line 1, column 9:",
+				"SecRule \\  ",
+				"         ^^",
+				"",
+			),
+		},
+		{
+			name: "POSITIVE - single column error with tab start expanded",
 			fields: fields{
 				Offset:     1,
 				Distance:   1,
@@ -54,8 +152,8 @@ func TestLinterError_Error(t *testing.T) {
 				"",
 				"Error: This column is wrong",
 				"line 1, column 1:",
-				"	SecRule optionA optionB",
-				"	^",
+				"    SecRule optionA optionB",
+				"    ^",
 				"",
 			),
 		},
@@ -158,10 +256,34 @@ func TestLinterError_Error(t *testing.T) {
 				"",
 			),
 		},
+		{
+			name: "POSITIVE - long single-line text error ending early",
+			fields: fields{
+				Offset:     8,
+				Distance:   7,
+				Message:    "This column is wrong",
+				ParseLevel: ParseLevelError,
+				Content: joinString(
+					`SecRule optionA "this single option is way too long so it will be split into two lines"`,
+				),
+			},
+			want: joinString(
+				"",
+				"Error: This column is wrong",
+				"line 1, column 8:",
+				`SecRule optionA "this single option is way too long so it will be split into two`,
+				`        ^^^^^^^`,
+				`     lines"`,
+				``,
+				"",
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := &LinterError{
+				File:       tt.fields.File,
+				Kind:       tt.fields.Kind,
 				Offset:     tt.fields.Offset,
 				Distance:   tt.fields.Distance,
 				Message:    tt.fields.Message,
@@ -174,3 +296,167 @@ func TestLinterError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestLinterErrors(t *testing.T) {
+	first := &LinterError{Message: "first"}
+	second := &LinterError{Message: "second"}
+
+	tests := []struct {
+		name string
+		err  error
+		want []*LinterError
+	}{
+		{
+			name: "POSITIVE - single linter error",
+			err:  first,
+			want: []*LinterError{first},
+		},
+		{
+			name: "POSITIVE - wrapped and joined linter errors",
+			err:  fmt.Errorf("findings: %w", errors.Join(first, fmt.Errorf("wrapped: %w", second))),
+			want: []*LinterError{first, second},
+		},
+		{
+			name: "NEGATIVE - no linter errors",
+			err:  errors.New("not a linter error"),
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(LinterErrors(tt.err), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestLinterErrorIs(t *testing.T) {
+	quote := &LinterError{Kind: ErrUnterminatedQuote, Message: "unterminated single quote in action list"}
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "POSITIVE - kind of the error",
+			err:    quote,
+			target: ErrUnterminatedQuote,
+			want:   true,
+		},
+		{
+			name:   "POSITIVE - kind of a joined error",
+			err:    errors.Join(&LinterError{Kind: ErrEmptyAction}, quote),
+			target: ErrUnterminatedQuote,
+			want:   true,
+		},
+		{
+			name:   "NEGATIVE - other kind",
+			err:    quote,
+			target: ErrEmptyAction,
+			want:   false,
+		},
+		{
+			name:   "NEGATIVE - error without a kind",
+			err:    &LinterError{Message: "no kind"},
+			target: ErrEmptyAction,
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinterErrorPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "POSITIVE - start of the file",
+			offset:     0,
+			wantLine:   1,
+			wantColumn: 0,
+		},
+		{
+			name:       "POSITIVE - within a later line",
+			offset:     17,
+			wantLine:   2,
+			wantColumn: 3,
+		},
+		{
+			name:       "POSITIVE - end of the file",
+			offset:     26,
+			wantLine:   2,
+			wantColumn: 12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &LinterError{Offset: tt.offset, Contents: "SecMarker END\nSecMarker END"}
+
+			line, column := e.Position()
+			if diff := deep.Equal([]int{line, column}, []int{tt.wantLine, tt.wantColumn}); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestLinterErrorEndPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int
+		distance   int
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "POSITIVE - last byte of a single line error",
+			offset:     10,
+			distance:   3,
+			wantLine:   1,
+			wantColumn: 12,
+		},
+		{
+			name:       "POSITIVE - error ending on a later line",
+			offset:     10,
+			distance:   8,
+			wantLine:   2,
+			wantColumn: 3,
+		},
+		{
+			name:       "POSITIVE - error without a distance",
+			offset:     17,
+			distance:   0,
+			wantLine:   2,
+			wantColumn: 3,
+		},
+		{
+			name:       "NEGATIVE - error past the end of the file",
+			offset:     20,
+			distance:   40,
+			wantLine:   2,
+			wantColumn: 12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &LinterError{Offset: tt.offset, Distance: tt.distance, Contents: "SecMarker END\nSecMarker END"}
+
+			line, column := e.EndPosition()
+			if diff := deep.Equal([]int{line, column}, []int{tt.wantLine, tt.wantColumn}); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
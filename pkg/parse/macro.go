@@ -0,0 +1,84 @@
+package parse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// represents a macro expanded by Coraza at runtime.
+// Examples:
+//   - %{MATCHED_VAR}
+//   - %{tx.critical_anomaly_score}
+type Macro struct {
+	// name of the variable or collection, ex. "tx"
+	Name string
+
+	// key selecting a member of the collection, empty if the
+	// macro expands a variable, ex. "critical_anomaly_score"
+	Key string
+
+	// raw macro text as written, ex. "%{tx.critical_anomaly_score}"
+	Lexeme string
+
+	// offset of the macro within the entire file
+	Offset int
+}
+
+// Returns the length of the macro lexeme
+func (m *Macro) Len() int {
+	return len(m.Lexeme)
+}
+
+// parses all macros within the given text found at the given offset.
+// Contents represents the entire read content the text came from.
+func ParseMacros(contents []byte, text string, offset int) ([]*Macro, error) {
+	patternMacroContent := regexp.MustCompile(`^[[:alpha:]_]+(\.[^{}%\s]+)?$`)
+
+	var macros []*Macro
+
+	for searched := 0; ; {
+		start := strings.Index(text[searched:], "%{")
+		if start == -1 {
+			return macros, nil
+		}
+
+		start += searched
+
+		length := strings.IndexByte(text[start:], '}')
+		if length == -1 {
+			return nil, &LinterError{
+				Kind:       ErrInvalidMacro,
+				Message:    "unterminated macro, expected '}'",
+				ParseLevel: ParseLevelError,
+				Offset:     offset + start,
+				Distance:   len(text) - start,
+				Contents:   string(contents),
+			}
+		}
+
+		lexeme := text[start : start+length+1]
+		content := lexeme[2 : len(lexeme)-1]
+
+		if !patternMacroContent.MatchString(content) {
+			return nil, &LinterError{
+				Kind:       ErrInvalidMacro,
+				Message:    "invalid macro, expected %{VARIABLE} or %{COLLECTION.key}",
+				ParseLevel: ParseLevelError,
+				Offset:     offset + start,
+				Distance:   len(lexeme),
+				Contents:   string(contents),
+			}
+		}
+
+		name, key, _ := strings.Cut(content, ".")
+
+		macros = append(macros, &Macro{
+			Name:   name,
+			Key:    key,
+			Lexeme: lexeme,
+			Offset: offset + start,
+		})
+
+		searched = start + len(lexeme)
+	}
+}
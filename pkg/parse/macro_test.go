@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseMacros(t *testing.T) {
+	type args struct {
+		text   string
+		offset int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*Macro
+		wantErr bool
+	}{
+		{
+			name: "POSITIVE - no macros",
+			args: args{
+				text: "Method is not allowed by policy",
+			},
+			want: nil,
+		},
+		{
+			name: "POSITIVE - variable and collection macros",
+			args: args{
+				text:   "Matched %{MATCHED_VAR_NAME} with %{TX.blocking_paranoia_level}",
+				offset: 10,
+			},
+			want: []*Macro{
+				{
+					Name:   "MATCHED_VAR_NAME",
+					Lexeme: "%{MATCHED_VAR_NAME}",
+					Offset: 18,
+				},
+				{
+					Name:   "TX",
+					Key:    "blocking_paranoia_level",
+					Lexeme: "%{TX.blocking_paranoia_level}",
+					Offset: 43,
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - unterminated macro",
+			args: args{
+				text: "score %{tx.score",
+			},
+			wantErr: true,
+		},
+		{
+			name: "NEGATIVE - empty macro",
+			args: args{
+				text: "score %{}",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMacros([]byte(tt.args.text), tt.args.text, tt.args.offset)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMacros() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
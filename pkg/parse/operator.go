@@ -0,0 +1,163 @@
+package parse
+
+import "strings"
+
+// all operator names supported by Coraza
+const (
+	OperatorBeginsWith           = "beginsWith"
+	OperatorContains             = "contains"
+	OperatorContainsWord         = "containsWord"
+	OperatorDetectSQLi           = "detectSQLi"
+	OperatorDetectXSS            = "detectXSS"
+	OperatorEndsWith             = "endsWith"
+	OperatorEq                   = "eq"
+	OperatorGe                   = "ge"
+	OperatorGeoLookup            = "geoLookup"
+	OperatorGt                   = "gt"
+	OperatorInspectFile          = "inspectFile"
+	OperatorIPMatch              = "ipMatch"
+	OperatorIPMatchF             = "ipMatchF"
+	OperatorIPMatchFromFile      = "ipMatchFromFile"
+	OperatorLe                   = "le"
+	OperatorLt                   = "lt"
+	OperatorNoMatch              = "noMatch"
+	OperatorPm                   = "pm"
+	OperatorPmf                  = "pmf"
+	OperatorPmFromFile           = "pmFromFile"
+	OperatorRbl                  = "rbl"
+	OperatorRestpath             = "restpath"
+	OperatorRx                   = "rx"
+	OperatorStreq                = "streq"
+	OperatorStrmatch             = "strmatch"
+	OperatorUnconditionalMatch   = "unconditionalMatch"
+	OperatorValidateByteRange    = "validateByteRange"
+	OperatorValidateNid          = "validateNid"
+	OperatorValidateSchema       = "validateSchema"
+	OperatorValidateURLEncoding  = "validateUrlEncoding"
+	OperatorValidateUTF8Encoding = "validateUtf8Encoding"
+	OperatorWithin               = "within"
+)
+
+// returns all operator names supported by Coraza as a string slice.
+func OperatorNames() []string {
+	return []string{
+		OperatorBeginsWith,
+		OperatorContains,
+		OperatorContainsWord,
+		OperatorDetectSQLi,
+		OperatorDetectXSS,
+		OperatorEndsWith,
+		OperatorEq,
+		OperatorGe,
+		OperatorGeoLookup,
+		OperatorGt,
+		OperatorInspectFile,
+		OperatorIPMatch,
+		OperatorIPMatchF,
+		OperatorIPMatchFromFile,
+		OperatorLe,
+		OperatorLt,
+		OperatorNoMatch,
+		OperatorPm,
+		OperatorPmf,
+		OperatorPmFromFile,
+		OperatorRbl,
+		OperatorRestpath,
+		OperatorRx,
+		OperatorStreq,
+		OperatorStrmatch,
+		OperatorUnconditionalMatch,
+		OperatorValidateByteRange,
+		OperatorValidateNid,
+		OperatorValidateSchema,
+		OperatorValidateURLEncoding,
+		OperatorValidateUTF8Encoding,
+		OperatorWithin,
+	}
+}
+
+// represents the operator option of a SecRule.
+// Examples:
+//   - @pmFromFile scanners-user-agents.data
+//   - !@eq 0
+//   - ^(?:GET|HEAD)$
+type Operator struct {
+	// name of the operator without '@', "rx" if the
+	// operator is implicit, ex. "pmFromFile"
+	Name string
+
+	// argument of the operator as written, keeping escapes
+	// and line continuations, ex. "scanners-user-agents.data"
+	Argument string
+
+	// reports whether the operator is negated with '!'
+	Negated bool
+
+	// reports whether the operator is written as a bare
+	// regular expression without '@rx'
+	Implicit bool
+
+	// offset of the operator within the entire file,
+	// pointing at '@' unless the operator is implicit
+	Offset int
+
+	// offset of the argument within the entire file
+	ArgumentOffset int
+}
+
+// parses the operator held by the given option.
+// Contents represents the entire read content the option came from.
+func ParseOperator(contents []byte, option *Option) (*Operator, error) {
+	body := option.Lexeme
+	offset := option.Offset
+
+	if len(body) >= 2 && body[0] == '"' && body[len(body)-1] == '"' {
+		body = body[1 : len(body)-1]
+		offset++
+	}
+
+	operator := &Operator{}
+
+	if strings.HasPrefix(body, "!") {
+		operator.Negated = true
+		body = body[1:]
+		offset++
+	}
+
+	if !strings.HasPrefix(body, "@") {
+		operator.Name = OperatorRx
+		operator.Argument = body
+		operator.Implicit = true
+		operator.Offset = offset
+		operator.ArgumentOffset = offset
+
+		return operator, nil
+	}
+
+	nameEnd := strings.IndexFunc(body, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\\' || r == '\n' || r == '\r'
+	})
+	if nameEnd == -1 {
+		nameEnd = len(body)
+	}
+
+	if nameEnd == 1 {
+		return nil, &LinterError{
+			Kind:       ErrInvalidOperator,
+			Message:    "expected operator name after '@'",
+			ParseLevel: ParseLevelError,
+			Offset:     offset,
+			Distance:   1,
+			Contents:   string(contents),
+		}
+	}
+
+	operator.Name = body[1:nameEnd]
+	operator.Offset = offset
+
+	start, end := trimContinuation(body[nameEnd:])
+	operator.Argument = body[nameEnd+start : nameEnd+end]
+	operator.ArgumentOffset = offset + nameEnd + start
+
+	return operator, nil
+}
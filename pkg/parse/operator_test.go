@@ -0,0 +1,109 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseOperator(t *testing.T) {
+	type args struct {
+		contents []byte
+		option   *Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Operator
+		wantErr bool
+	}{
+		{
+			name: "POSITIVE - explicit operator with argument",
+			args: args{
+				contents: []byte(`SecRule ARGS "@pmFromFile unix-shell.data" "id:1"`),
+				option: &Option{
+					Lexeme: `"@pmFromFile unix-shell.data"`,
+					Offset: 13,
+				},
+			},
+			want: &Operator{
+				Name:           "pmFromFile",
+				Argument:       "unix-shell.data",
+				Offset:         14,
+				ArgumentOffset: 26,
+			},
+		},
+		{
+			name: "POSITIVE - negated operator",
+			args: args{
+				contents: []byte(`SecRule TX:a "!@eq 0" "id:1"`),
+				option: &Option{
+					Lexeme: `"!@eq 0"`,
+					Offset: 13,
+				},
+			},
+			want: &Operator{
+				Name:           "eq",
+				Argument:       "0",
+				Negated:        true,
+				Offset:         15,
+				ArgumentOffset: 19,
+			},
+		},
+		{
+			name: "POSITIVE - operator without argument",
+			args: args{
+				contents: []byte(`SecRule ARGS "@detectSQLi" "id:1"`),
+				option: &Option{
+					Lexeme: `"@detectSQLi"`,
+					Offset: 13,
+				},
+			},
+			want: &Operator{
+				Name:           "detectSQLi",
+				Offset:         14,
+				ArgumentOffset: 25,
+			},
+		},
+		{
+			name: "POSITIVE - implicit regular expression",
+			args: args{
+				contents: []byte(`SecRule ARGS "^(?:a|b)$" "id:1"`),
+				option: &Option{
+					Lexeme: `"^(?:a|b)$"`,
+					Offset: 13,
+				},
+			},
+			want: &Operator{
+				Name:           "rx",
+				Argument:       "^(?:a|b)$",
+				Implicit:       true,
+				Offset:         14,
+				ArgumentOffset: 14,
+			},
+		},
+		{
+			name: "NEGATIVE - missing operator name",
+			args: args{
+				contents: []byte(`SecRule ARGS "@ a" "id:1"`),
+				option: &Option{
+					Lexeme: `"@ a"`,
+					Offset: 13,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOperator(tt.args.contents, tt.args.option)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseOperator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package parse
 
 import (
-	"fmt"
 	"regexp"
+	"strings"
 )
 
 // represents a single option of a SecLang directive
@@ -28,11 +28,11 @@ func (o *Option) Len() int {
 // returns the option lexeme with the following edits
 // for easier analysis of the option contents:
 //   - without start and end quotes
-//   - escaped newlines converted to space
+//   - escaped newlines, with or without carriage return, converted to space
 //   - escaped double quotes converted to non-escaped double quote
 func (o *Option) Content() string {
 	var (
-		patternNewlineEscaped = regexp.MustCompile(`\\\n`)
+		patternNewlineEscaped = regexp.MustCompile(`\\\r?\n`)
 		patternQuoteEscaped   = regexp.MustCompile(`\\"`)
 	)
 
@@ -53,10 +53,69 @@ func (o *Option) Content() string {
 	return content
 }
 
+// represents a whitespace separated word within an option
+type Word struct {
+	// text of the word
+	Text string
+
+	// offset of the word within the entire file
+	Offset int
+}
+
+// returns the raw option lexeme without enclosing double quotes,
+// keeping escapes and line continuations as written
+func (o *Option) Body() Word {
+	body := o.Lexeme
+
+	if len(body) >= 2 && body[0] == '"' && body[len(body)-1] == '"' {
+		return Word{Text: body[1 : len(body)-1], Offset: o.Offset + 1}
+	}
+
+	return Word{Text: body, Offset: o.Offset}
+}
+
+// splits the option into whitespace separated words, line
+// continuations separating words like whitespace does
+func (o *Option) Words() []Word {
+	var (
+		words  []Word
+		raw    = o.Body()
+		body   = raw.Text
+		offset = raw.Offset
+	)
+
+	start := -1
+
+	for i := 0; i <= len(body); i++ {
+		length := 0
+		if i < len(body) {
+			length = continuationLen(body[i:])
+		}
+
+		separator := i == len(body) || length > 0 || isSpace(body[i])
+
+		switch {
+		case separator && start != -1:
+			words = append(words, Word{Text: body[start:i], Offset: offset + start})
+			start = -1
+		case !separator && start == -1:
+			start = i
+		}
+
+		// the line break of a continuation is skipped along with it
+		if length > 0 {
+			i += length - 1
+		}
+	}
+
+	return words
+}
+
 // parses non quoted option content into option object
 func ParseOptionNotQuoted(contents []byte, offset int) (*Option, error) {
 	if offset >= len(contents) {
 		return nil, &LinterError{
+			Kind:       ErrUnexpectedToken,
 			Message:    "EOF - expected unquoted option content",
 			ParseLevel: ParseLevelError,
 			Offset:     offset,
@@ -79,6 +138,7 @@ func ParseOptionNotQuoted(contents []byte, offset int) (*Option, error) {
 	}
 
 	return nil, &LinterError{
+		Kind:       ErrUnexpectedToken,
 		Message:    "found unexpected whitepsace while scanning unquoted option syntax",
 		ParseLevel: ParseLevelError,
 		Offset:     offset,
@@ -91,6 +151,7 @@ func ParseOptionNotQuoted(contents []byte, offset int) (*Option, error) {
 func ParseOptionQuoted(contents []byte, offset int) (*Option, error) {
 	if offset >= len(contents) {
 		return nil, &LinterError{
+			Kind:       ErrUnterminatedQuote,
 			Message:    "EOF - expected quoted option content",
 			ParseLevel: ParseLevelError,
 			Offset:     offset,
@@ -114,6 +175,7 @@ func ParseOptionQuoted(contents []byte, offset int) (*Option, error) {
 	}
 
 	return nil, &LinterError{
+		Kind:       ErrUnterminatedQuote,
 		Message:    "unexpected sequence while scanning quoted option syntax",
 		ParseLevel: ParseLevelError,
 		Offset:     offset,
@@ -131,14 +193,12 @@ func ParseOptions(contents []byte, offset int) ([]*Option, error) {
 		nil,
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"trouble parsing options: %w",
-			err,
-		)
+		return nil, err
 	}
 
 	if len(options) == 0 {
 		return nil, &LinterError{
+			Kind:       ErrMissingOptions,
 			Message:    "expecting directive options",
 			ParseLevel: ParseLevelError,
 			Offset:     offset,
@@ -156,8 +216,9 @@ func parseOptions(contents []byte, offset int, options []*Option) ([]*Option, er
 	}
 
 	var (
-		patternNewline   = regexp.MustCompile(`^\n`)
-		patternSkip      = regexp.MustCompile(`^(\\\n| )`)
+		patternNewline   = regexp.MustCompile(`^\r?\n`)
+		patternSkip      = regexp.MustCompile(`^(\\\r?\n| )`)
+		patternBroken    = regexp.MustCompile(`^\\([ \t]+)(\r?\n|$)`)
 		patternNotQuoted = regexp.MustCompile(`^[^"]`)
 		patternQuoted    = regexp.MustCompile(`^"`)
 	)
@@ -176,10 +237,14 @@ func parseOptions(contents []byte, offset int, options []*Option) ([]*Option, er
 		return options, nil
 	}
 
+	if matchIndex := patternBroken.FindSubmatchIndex(offsetContent); matchIndex != nil {
+		return nil, ContinuationWhitespaceError(contents, offset+matchIndex[2], matchIndex[3]-matchIndex[2])
+	}
+
 	if matchIndex := patternNotQuoted.FindIndex(offsetContent); matchIndex != nil {
 		option, err := ParseOptionNotQuoted(contents, offset)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse option: %w", err)
+			return nil, err
 		}
 
 		return parseOptions(
@@ -192,7 +257,7 @@ func parseOptions(contents []byte, offset int, options []*Option) ([]*Option, er
 	if matchIndex := patternQuoted.FindIndex(offsetContent); matchIndex != nil {
 		option, err := ParseOptionQuoted(contents, offset)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse option: %w", err)
+			return nil, err
 		}
 
 		return parseOptions(
@@ -203,6 +268,7 @@ func parseOptions(contents []byte, offset int, options []*Option) ([]*Option, er
 	}
 
 	return nil, &LinterError{
+		Kind:       ErrUnexpectedToken,
 		Message:    "unexpected token",
 		Offset:     offset,
 		Distance:   1,
@@ -210,3 +276,52 @@ func parseOptions(contents []byte, offset int, options []*Option) ([]*Option, er
 		Contents:   string(contents),
 	}
 }
+
+// returns the length of the line continuation starting the text,
+// a backslash followed by "\n" or "\r\n", or 0 if there is none
+func continuationLen(text string) int {
+	switch {
+	case strings.HasPrefix(text, "\\\n"):
+		return 2
+	case strings.HasPrefix(text, "\\\r\n"):
+		return 3
+	}
+
+	return 0
+}
+
+// returns the length of the line continuation ending the text,
+// a backslash followed by "\n" or "\r\n", or 0 if there is none
+func continuationSuffixLen(text string) int {
+	switch {
+	case strings.HasSuffix(text, "\\\n"):
+		return 2
+	case strings.HasSuffix(text, "\\\r\n"):
+		return 3
+	}
+
+	return 0
+}
+
+// Reports whether the text ends with a line continuation, meaning
+// the line following the text continues the same directive
+func EndsWithContinuation(text string) bool {
+	return continuationSuffixLen(text) > 0
+}
+
+// returns the error of whitespace written at the given offset, after
+// a backslash meant to continue the directive on the next line
+func ContinuationWhitespaceError(contents []byte, offset, distance int) *LinterError {
+	return &LinterError{
+		Kind:       ErrContinuationWhitespace,
+		Message:    "whitespace after the line continuation backslash, the line is not continued",
+		ParseLevel: ParseLevelError,
+		Offset:     offset,
+		Distance:   distance,
+		Contents:   string(contents),
+		Fix: &SuggestedFix{
+			Message: "remove the whitespace after the backslash",
+			Edits:   []TextEdit{{Offset: offset, Distance: distance}},
+		},
+	}
+}
@@ -175,6 +175,27 @@ func TestParseOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "POSITIVE - Parse two unquoted options with escaped carriage return newline",
+			args: args{
+				contents: []byte(
+					"optionA \\\r\n" +
+						"optionB\r\n" +
+						"Directive optionC",
+				),
+				offset: 0,
+			},
+			want: []*Option{
+				{
+					Lexeme: "optionA",
+					Offset: 0,
+				},
+				{
+					Lexeme: "optionB",
+					Offset: 11,
+				},
+			},
+		},
 		{
 			name: "POSITIVE - Parse two quoted options with escaped newline",
 			args: args{
@@ -258,6 +279,60 @@ func TestParseOptions(t *testing.T) {
 	}
 }
 
+func TestParseOptionsContinuationWhitespace(t *testing.T) {
+	tests := []struct {
+		name         string
+		contents     string
+		wantOffset   int
+		wantDistance int
+		wantFixed    string
+	}{
+		{
+			name:         "NEGATIVE - space after a continuation",
+			contents:     "optionA \\ \n    optionB",
+			wantOffset:   9,
+			wantDistance: 1,
+			wantFixed:    "optionA \\\n    optionB",
+		},
+		{
+			name:         "NEGATIVE - spaces and tabs after a continuation before a carriage return",
+			contents:     "optionA \\ \t \r\n    optionB",
+			wantOffset:   9,
+			wantDistance: 3,
+			wantFixed:    "optionA \\\r\n    optionB",
+		},
+		{
+			name:         "NEGATIVE - whitespace after a continuation at the end of the file",
+			contents:     "optionA \\  ",
+			wantOffset:   9,
+			wantDistance: 2,
+			wantFixed:    "optionA \\",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOptions([]byte(tt.contents), 0)
+
+			linterError, ok := err.(*LinterError)
+			if !ok || linterError.Kind != ErrContinuationWhitespace {
+				t.Fatalf("expected an error of kind %v, got %v", ErrContinuationWhitespace, err)
+			}
+
+			if diff := deep.Equal(
+				[]int{linterError.Offset, linterError.Distance},
+				[]int{tt.wantOffset, tt.wantDistance},
+			); diff != nil {
+				t.Error(diff)
+			}
+
+			fixed, _ := ApplyFixes([]byte(tt.contents), []*SuggestedFix{linterError.Fix})
+			if diff := deep.Equal(string(fixed), tt.wantFixed); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func TestOption_Content(t *testing.T) {
 	type fields struct {
 		Lexeme string
@@ -297,6 +372,14 @@ func TestOption_Content(t *testing.T) {
 			},
 			want: `option A`,
 		},
+		{
+			name: "POSITIVE - quoted option with escaped carriage return newline",
+			fields: fields{
+				Lexeme: `"option` + "\\\r\n" +
+					`A"`,
+			},
+			want: `option A`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -310,3 +393,87 @@ func TestOption_Content(t *testing.T) {
 		})
 	}
 }
+
+func TestOption_Words(t *testing.T) {
+	tests := []struct {
+		name   string
+		option *Option
+		want   []Word
+	}{
+		{
+			name:   "POSITIVE - unquoted option",
+			option: &Option{Lexeme: "942100", Offset: 18},
+			want:   []Word{{Text: "942100", Offset: 18}},
+		},
+		{
+			name:   "POSITIVE - quoted option with several words",
+			option: &Option{Lexeme: `"942100  942200-942299"`, Offset: 18},
+			want: []Word{
+				{Text: "942100", Offset: 19},
+				{Text: "942200-942299", Offset: 27},
+			},
+		},
+		{
+			name:   "POSITIVE - words separated by a line continuation",
+			option: &Option{Lexeme: "\"1 \\\n    2\"", Offset: 0},
+			want: []Word{
+				{Text: "1", Offset: 1},
+				{Text: "2", Offset: 9},
+			},
+		},
+		{
+			name:   "POSITIVE - words separated by a windows line continuation",
+			option: &Option{Lexeme: "\"1\\\r\n    2\"", Offset: 0},
+			want: []Word{
+				{Text: "1", Offset: 1},
+				{Text: "2", Offset: 9},
+			},
+		},
+		{
+			name:   "NEGATIVE - empty quoted option",
+			option: &Option{Lexeme: `""`, Offset: 0},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := deep.Equal(tt.option.Words(), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestEndsWithContinuation(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{
+			name: "POSITIVE - continuation",
+			text: "SecAction \\\n",
+			want: true,
+		},
+		{
+			name: "POSITIVE - windows continuation",
+			text: "SecAction \\\r\n",
+			want: true,
+		},
+		{
+			name: "NEGATIVE - line break",
+			text: "SecMarker END\r\n",
+		},
+		{
+			name: "NEGATIVE - backslash at the end of the text",
+			text: "SecAction \\",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EndsWithContinuation(tt.text); got != tt.want {
+				t.Errorf("EndsWithContinuation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
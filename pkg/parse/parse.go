@@ -3,21 +3,25 @@ package parse
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/internal/jobs"
 )
 
 // Parses file structure using just the content
 func Parse(content []byte) (*File, error) {
 	directives, err := ParseDirectives(content)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"could not parse directives: %w",
-			err,
-		)
+		return nil, err
 	}
 
 	return &File{
+		contents:   content,
 		Directives: directives,
 	}, nil
 }
@@ -34,19 +38,75 @@ func ParseFile(name string) (*File, error) {
 		)
 	}
 
-	parsed, err := Parse(contents)
+	return parseNamed(name, contents)
+}
+
+// Parses file structure reading the contents from the
+// reader, the name is reported along with findings
+func ParseReader(name string, r io.Reader) (*File, error) {
+	contents, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"could not parse read file contents: %w", err,
+			"could not read file %q: %w",
+			name,
+			err,
 		)
 	}
 
+	return parseNamed(name, contents)
+}
+
+// parses the contents of the file with the given name
+func parseNamed(name string, contents []byte) (*File, error) {
+	parsed, err := Parse(contents)
+	if err != nil {
+		for _, linterError := range LinterErrors(err) {
+			linterError.File = name
+		}
+
+		return nil, err
+	}
+
+	parsed.name = name
+
 	return parsed, nil
 }
 
+// receives the progress of parsing the files matching glob patterns
+type Reporter interface {
+	// called for every matching file in match order once it is
+	// parsed, with the error if it could not be read or parsed
+	FileParsed(name string, file *File, err error)
+}
+
+// adapts a function to the Reporter interface
+type ReporterFunc func(name string, file *File, err error)
+
+// calls the function
+func (f ReporterFunc) FileParsed(name string, file *File, err error) {
+	f(name, file, err)
+}
+
+// options changing how files matching glob patterns are parsed
+type GlobOptions struct {
+	// number of files read and parsed at once,
+	// GOMAXPROCS when zero or less
+	Jobs int
+
+	// receives the progress of parsing, nil to parse silently
+	Reporter Reporter
+}
+
 // Parses file structure using the content of all
 // files that match the glob pattern
 func ParseGlob(patterns ...string) ([]*File, error) {
+	return ParseGlobWith(GlobOptions{}, patterns...)
+}
+
+// Parses file structure using the content of all files that
+// match the glob pattern, reading and parsing files concurrently
+// as the options allow. Files are returned in match order.
+func ParseGlobWith(options GlobOptions, patterns ...string) ([]*File, error) {
 	matches := make([]string, 0)
 
 	for _, pattern := range patterns {
@@ -61,29 +121,181 @@ func ParseGlob(patterns ...string) ([]*File, error) {
 		matches = append(matches, someMatches...)
 	}
 
+	var (
+		parsedFiles = make([]*File, len(matches))
+		parseErrs   = make([]error, len(matches))
+	)
+
+	jobs.Run(options.Jobs, len(matches), func(i int) {
+		parsedFiles[i], parseErrs[i] = ParseFile(matches[i])
+	})
+
 	errs := make([]error, 0, len(matches))
 	files := make([]*File, 0, len(matches))
 
-	for _, match := range matches {
-		fmt.Printf("validating file %s", match)
+	for i, match := range matches {
+		if options.Reporter != nil {
+			options.Reporter.FileParsed(match, parsedFiles[i], parseErrs[i])
+		}
 
-		parsedFile, err := ParseFile(match)
-		if err != nil {
-			errs = append(errs, err)
+		if parseErrs[i] != nil {
+			errs = append(errs, parseErrs[i])
 			continue
 		}
 
-		files = append(files, parsedFile)
-
-		fmt.Println(".........success!")
+		files = append(files, parsedFiles[i])
 	}
 
 	if len(errs) > 0 {
-		return nil, fmt.Errorf(
-			"Linter errors: \n%w",
-			errors.Join(errs...),
-		)
+		return nil, errors.Join(errs...)
 	}
 
 	return files, nil
 }
+
+// Parses file structure using the content of all files within the
+// file system that match the glob patterns. Files loaded with Include
+// are read from the same file system, relative to the including file,
+// and follow it in the returned files. Every file is parsed once, the
+// files loaded by an Include directive are available from File.Included.
+func ParseFS(fsys fs.FS, patterns ...string) ([]*File, error) {
+	p := &fsParser{fsys: fsys, parsed: map[string]bool{}}
+
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid glob pattern: %w",
+				err,
+			)
+		}
+
+		for _, match := range matches {
+			p.parse(match)
+		}
+	}
+
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+
+	return p.files, nil
+}
+
+// parses files of a file system, following Include directives
+type fsParser struct {
+	fsys fs.FS
+
+	// names of the files parsed so far, preventing
+	// files including each other from looping
+	parsed map[string]bool
+
+	// parsed files, in load order
+	files []*File
+
+	// errors of files which could not be read or parsed
+	errs []error
+}
+
+// parses the file with the given name along with the files it
+// includes, returning nil if it was parsed before or failed to parse
+func (p *fsParser) parse(name string) *File {
+	if p.parsed[name] {
+		return nil
+	}
+
+	p.parsed[name] = true
+
+	reader, err := p.fsys.Open(name)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf(
+			"could not read file %q: %w",
+			name,
+			err,
+		))
+
+		return nil
+	}
+
+	file, err := ParseReader(name, reader)
+
+	if closeErr := reader.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf(
+			"could not close file %q: %w",
+			name,
+			closeErr,
+		)
+	}
+
+	if err != nil {
+		p.errs = append(p.errs, err)
+
+		return nil
+	}
+
+	p.files = append(p.files, file)
+
+	for _, directive := range file.Directives {
+		if !strings.EqualFold(directive.Lexeme, DirectiveInclude) || len(directive.Options) == 0 {
+			continue
+		}
+
+		included := p.include(file, directive.Options[0])
+		if len(included) == 0 {
+			continue
+		}
+
+		if file.includes == nil {
+			file.includes = map[*Directive][]*File{}
+		}
+
+		file.includes[directive] = included
+	}
+
+	return file
+}
+
+// parses the files matching the pattern of an Include directive,
+// returning the files it loads for the first time in load order
+func (p *fsParser) include(file *File, option *Option) []*File {
+	pattern := option.Content()
+
+	includeError := func(message string) {
+		p.errs = append(p.errs, &LinterError{
+			File:       file.name,
+			Kind:       ErrInclude,
+			Message:    message,
+			ParseLevel: ParseLevelError,
+			Offset:     option.Offset,
+			Distance:   option.Len(),
+			Contents:   string(file.contents),
+		})
+	}
+
+	// paths of a file system are unrooted, so absolute
+	// paths can not be resolved within it
+	if path.IsAbs(pattern) || filepath.IsAbs(pattern) {
+		includeError(fmt.Sprintf("absolute Include path %q can not be resolved within the file system", pattern))
+
+		return nil
+	}
+
+	matches, err := fs.Glob(p.fsys, path.Join(path.Dir(file.name), pattern))
+
+	switch {
+	case err != nil:
+		includeError(fmt.Sprintf("invalid Include pattern %q: %v", pattern, err))
+	case len(matches) == 0:
+		includeError(fmt.Sprintf("Include %q matches no files", pattern))
+	}
+
+	var included []*File
+
+	for _, match := range matches {
+		if parsed := p.parse(match); parsed != nil {
+			included = append(included, parsed)
+		}
+	}
+
+	return included
+}
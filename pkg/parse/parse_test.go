@@ -0,0 +1,336 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseReader(t *testing.T) {
+	file, err := ParseReader("rules.conf", strings.NewReader("SecMarker END"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(file.Name(), "rules.conf"); diff != nil {
+		t.Error(diff)
+	}
+
+	want := []*Directive{
+		{
+			Lexeme:  "SecMarker",
+			Offset:  0,
+			Options: []*Option{{Lexeme: "END", Offset: 10}},
+		},
+	}
+
+	if diff := deep.Equal(file.Directives, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     *Kind
+	}{
+		{
+			name:     "NEGATIVE - unterminated double quote",
+			contents: `SecMarker "END`,
+			want:     ErrUnterminatedQuote,
+		},
+		{
+			name:     "NEGATIVE - directive without options",
+			contents: "SecRuleEngine\nSecMarker END",
+			want:     ErrMissingOptions,
+		},
+		{
+			name:     "NEGATIVE - whitespace after a line continuation",
+			contents: "SecRule ARGS \"@rx a\" \\ \n    \"id:1,phase:2,deny\"",
+			want:     ErrContinuationWhitespace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseReader("rules.conf", strings.NewReader(tt.contents))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected error of kind %v, got %v", tt.want, err)
+			}
+
+			// the error is returned without wrapping, along with the file name
+			linterError, ok := err.(*LinterError)
+			if !ok {
+				t.Fatalf("expected a *LinterError, got %T", err)
+			}
+
+			if diff := deep.Equal(linterError.File, "rules.conf"); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.conf":                {Data: []byte("Include rules/*.conf\nSecMarker MAIN")},
+		"rules/a.conf":             {Data: []byte("SecMarker A")},
+		"rules/b.conf":             {Data: []byte(`Include "../rules/a.conf"`)},
+		"loop.conf":                {Data: []byte("Include loop.conf")},
+		"lowercase.conf":           {Data: []byte("include rules/a.conf")},
+		"missing.conf":             {Data: []byte("Include nothing/*.conf")},
+		"absolute.conf":            {Data: []byte("Include /etc/coraza/rules.conf")},
+		"invalid/directive.conf":   {Data: []byte(`SecMarker "END`)},
+		"unmatched/not-rules.data": {Data: []byte("entry")},
+	}
+
+	tests := []struct {
+		name         string
+		patterns     []string
+		want         []string
+		wantMessages []string
+		wantErr      bool
+	}{
+		{
+			name:     "POSITIVE - included files follow the including file",
+			patterns: []string{"main.conf"},
+			want:     []string{"main.conf", "rules/a.conf", "rules/b.conf"},
+		},
+		{
+			name:     "POSITIVE - files matched and included are parsed once",
+			patterns: []string{"rules/*.conf", "main.conf"},
+			want:     []string{"rules/a.conf", "rules/b.conf", "main.conf"},
+		},
+		{
+			name:     "POSITIVE - lowercase Include directive",
+			patterns: []string{"lowercase.conf"},
+			want:     []string{"lowercase.conf", "rules/a.conf"},
+		},
+		{
+			name:     "POSITIVE - file including itself is parsed once",
+			patterns: []string{"loop.conf"},
+			want:     []string{"loop.conf"},
+		},
+		{
+			name:     "POSITIVE - pattern without matches",
+			patterns: []string{"unmatched/*.conf"},
+			want:     nil,
+		},
+		{
+			name:         "NEGATIVE - Include without matches",
+			patterns:     []string{"missing.conf"},
+			wantMessages: []string{`Include "nothing/*.conf" matches no files`},
+			wantErr:      true,
+		},
+		{
+			name:         "NEGATIVE - absolute Include path",
+			patterns:     []string{"absolute.conf"},
+			wantMessages: []string{`absolute Include path "/etc/coraza/rules.conf" can not be resolved within the file system`},
+			wantErr:      true,
+		},
+		{
+			name:         "NEGATIVE - file which does not parse",
+			patterns:     []string{"invalid/*.conf"},
+			wantMessages: []string{"unexpected sequence while scanning quoted option syntax"},
+			wantErr:      true,
+		},
+		{
+			name:     "NEGATIVE - invalid glob pattern",
+			patterns: []string{"[.conf"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := ParseFS(fsys, tt.patterns...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+
+			if diff := deep.Equal(names, tt.want); diff != nil {
+				t.Error(diff)
+			}
+
+			var messages []string
+			for _, linterError := range LinterErrors(err) {
+				messages = append(messages, linterError.Message)
+			}
+
+			if diff := deep.Equal(messages, tt.wantMessages); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseGlobWith(t *testing.T) {
+	dir := t.TempDir()
+
+	var want []string
+
+	for i := range 20 {
+		name := filepath.Join(dir, fmt.Sprintf("rules-%02d.conf", i))
+		if err := os.WriteFile(name, []byte(fmt.Sprintf("SecMarker M%d", i)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		want = append(want, name)
+	}
+
+	for _, jobs := range []int{0, 1, 4, 32} {
+		t.Run(fmt.Sprintf("POSITIVE - %d jobs keep match order", jobs), func(t *testing.T) {
+			var reported []string
+
+			files, err := ParseGlobWith(GlobOptions{
+				Jobs: jobs,
+				Reporter: ReporterFunc(func(name string, file *File, err error) {
+					reported = append(reported, name)
+				}),
+			}, filepath.Join(dir, "*.conf"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(reported, want); diff != nil {
+				t.Error(diff)
+			}
+
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+
+			if diff := deep.Equal(names, want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseGlobWithReporterErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, contents := range map[string]string{
+		"a.conf": "SecMarker A",
+		"b.conf": `SecMarker "B`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type report struct {
+		Name       string
+		Directives int
+		Failed     bool
+	}
+
+	var reported []report
+
+	_, err := ParseGlobWith(GlobOptions{
+		Reporter: ReporterFunc(func(name string, file *File, err error) {
+			r := report{Name: filepath.Base(name), Failed: err != nil}
+			if file != nil {
+				r.Directives = len(file.Directives)
+			}
+
+			reported = append(reported, r)
+		}),
+	}, filepath.Join(dir, "*.conf"))
+	if err == nil {
+		t.Fatal("expected an error for the file which does not parse")
+	}
+
+	want := []report{
+		{Name: "a.conf", Directives: 1},
+		{Name: "b.conf", Failed: true},
+	}
+
+	if diff := deep.Equal(reported, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+// file system whose files fail to close
+type closeErrorFS struct {
+	fstest.MapFS
+}
+
+// opens the file of the underlying file system
+func (c closeErrorFS) Open(name string) (fs.File, error) {
+	file, err := c.MapFS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return closeErrorFile{file}, nil
+}
+
+// file failing to close
+type closeErrorFile struct {
+	fs.File
+}
+
+// returns an error after closing the underlying file
+func (c closeErrorFile) Close() error {
+	if err := c.File.Close(); err != nil {
+		return err
+	}
+
+	return errors.New("close failed")
+}
+
+func TestParseFSCloseError(t *testing.T) {
+	fsys := closeErrorFS{fstest.MapFS{"rules.conf": {Data: []byte("SecMarker END")}}}
+
+	_, err := ParseFS(fsys, "rules.conf")
+	if err == nil || !strings.Contains(err.Error(), "close failed") {
+		t.Fatalf("expected the error closing the file, got %v", err)
+	}
+}
+
+func TestParseFSIncluded(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.conf":    {Data: []byte("SecMarker START\nInclude rules/*.conf\nInclude rules/a.conf")},
+		"rules/a.conf": {Data: []byte("SecMarker A")},
+		"rules/b.conf": {Data: []byte("SecMarker B")},
+	}
+
+	files, err := ParseFS(fsys, "main.conf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	main := files[0]
+
+	var got [][]string
+
+	for _, directive := range main.Directives {
+		var names []string
+		for _, included := range main.Included(directive) {
+			names = append(names, included.Name())
+		}
+
+		got = append(got, names)
+	}
+
+	// files loaded before are not loaded again
+	want := [][]string{nil, {"rules/a.conf", "rules/b.conf"}, nil}
+
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,224 @@
+package parse
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// options changing how linter errors are rendered
+type RenderOptions struct {
+	// columns long lines are wrapped at,
+	// 80 when zero or less
+	Width int
+
+	// number of lines shown above and below the underlined lines
+	Context int
+
+	// reports whether the level, message and carets
+	// are highlighted with ANSI colors
+	Color bool
+}
+
+// ANSI escape sequences of highlighted text
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiBlue   = "\x1b[34m"
+	ansiCyan   = "\x1b[36m"
+)
+
+const (
+	// columns lines are wrapped at when no width is given
+	defaultWidth = 80
+
+	// fewest columns lines are wrapped at, so the parts of
+	// a wrapped line hold some text after their indentation
+	minWidth = 20
+
+	// columns between tab stops
+	tabWidth = 4
+
+	// indentation of the parts of a wrapped line after the first
+	wrapIndent = "    "
+)
+
+// represents a grapheme of a rendered line
+type cell struct {
+	// text shown for the grapheme, with tabs expanded to spaces
+	text string
+
+	// columns taken by the text
+	width int
+
+	// reports whether the grapheme is a space or a tab
+	space bool
+
+	// reports whether the grapheme is within the error
+	underlined bool
+}
+
+// returns the lines of the error underlined with carets, along
+// with the context lines around them, wrapping long lines
+func (e *LinterError) underlined(options RenderOptions) string {
+	var (
+		builder strings.Builder
+		width   = options.Width
+		start   = min(e.Offset, len(e.Contents))
+		end     = min(max(e.OffsetEnd(), start), len(e.Contents))
+		lines   = strings.Split(e.Contents, "\n")
+	)
+
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	width = max(width, minWidth)
+
+	// the line break ending the error belongs to its last line
+	first := strings.Count(e.Contents[:start], "\n")
+	last := first
+	if end > start {
+		last = strings.Count(e.Contents[:end-1], "\n")
+	}
+
+	// spaces and tabs are only underlined when nothing else is,
+	// such as whitespace after a line continuation
+	underlineSpace := end > start && strings.Trim(e.Contents[start:end], " \t") == ""
+
+	lineStart := 0
+
+	for i, line := range lines {
+		if i > last+options.Context {
+			break
+		}
+
+		// the empty text after the final line break is not a line
+		shown := i >= first-options.Context && (i <= last || i < len(lines)-1 || line != "")
+
+		if shown {
+			cells := lineCells(strings.TrimSuffix(line, "\r"), lineStart, start, end)
+
+			for j, segment := range wrapCells(cells, width) {
+				prefix := ""
+				if j > 0 {
+					prefix = wrapIndent
+				}
+
+				builder.WriteString(prefix + renderText(segment) + "\n")
+
+				if i < first || i > last {
+					continue
+				}
+
+				carets := strings.TrimRight(prefix+renderCarets(segment, underlineSpace), " ")
+				if options.Color && carets != "" {
+					carets = ColorLevel(e.ParseLevel, carets)
+				}
+
+				builder.WriteString(carets + "\n")
+			}
+		}
+
+		lineStart += len(line) + 1
+	}
+
+	return builder.String()
+}
+
+// splits the line starting at the given offset into cells, the
+// ones between start and end, exclusive, being underlined
+func lineCells(line string, offset, start, end int) []cell {
+	var (
+		cells  []cell
+		column int
+		state  = -1
+	)
+
+	for i := 0; i < len(line); {
+		var (
+			grapheme string
+			width    int
+		)
+
+		grapheme, _, width, state = uniseg.FirstGraphemeClusterInString(line[i:], state)
+		size := len(grapheme)
+
+		c := cell{underlined: offset+i < end && offset+i+size > start}
+
+		switch {
+		case grapheme == "\t":
+			c.width = tabWidth - column%tabWidth
+			c.text = strings.Repeat(" ", c.width)
+			c.space = true
+		case grapheme == " ":
+			c.text, c.width, c.space = " ", 1, true
+		case !utf8.ValidString(grapheme) || strings.ContainsFunc(grapheme, unicode.IsControl):
+			// control characters would move the cursor
+			c.text, c.width = string(utf8.RuneError), 1
+		default:
+			c.text, c.width = grapheme, width
+		}
+
+		cells = append(cells, c)
+		column += c.width
+		i += size
+	}
+
+	return cells
+}
+
+// splits the cells into parts fitting within the width, the parts
+// after the first leaving room for the indentation of wrapped lines
+func wrapCells(cells []cell, width int) [][]cell {
+	var (
+		segments = [][]cell{nil}
+		used     int
+		limit    = width
+	)
+
+	for _, c := range cells {
+		if used > 0 && used+c.width > limit {
+			segments = append(segments, nil)
+			used, limit = 0, width-len(wrapIndent)
+		}
+
+		segments[len(segments)-1] = append(segments[len(segments)-1], c)
+		used += c.width
+	}
+
+	return segments
+}
+
+// returns the text of the cells
+func renderText(cells []cell) string {
+	var builder strings.Builder
+
+	for _, c := range cells {
+		builder.WriteString(c.text)
+	}
+
+	return builder.String()
+}
+
+// returns the carets under the underlined cells, which are
+// as wide as the grapheme they point at
+func renderCarets(cells []cell, underlineSpace bool) string {
+	var builder strings.Builder
+
+	for _, c := range cells {
+		if c.underlined && (!c.space || underlineSpace) {
+			builder.WriteString(strings.Repeat("^", c.width))
+
+			continue
+		}
+
+		builder.WriteString(strings.Repeat(" ", c.width))
+	}
+
+	return builder.String()
+}
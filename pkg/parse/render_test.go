@@ -0,0 +1,171 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestLinterError_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		offset   int
+		distance int
+		options  RenderOptions
+		want     string
+	}{
+		{
+			name:     "POSITIVE - multibyte characters take a caret each",
+			contents: `SecAction "msg:'café déjà vu'"`,
+			offset:   16,
+			distance: 15,
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 16:",
+				`SecAction "msg:'café déjà vu'"`,
+				`                ^^^^ ^^^^ ^^`,
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - wide characters take two carets",
+			contents: `SecAction "msg:'日本語'"`,
+			offset:   16,
+			distance: 9,
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 16:",
+				`SecAction "msg:'日本語'"`,
+				`                ^^^^^^`,
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - combining marks and joined emoji are single graphemes",
+			contents: "SecAction \"msg:'e\u0301 \U0001F469\u200d\U0001F4BB x'\"",
+			offset:   32,
+			distance: 1,
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 32:",
+				"SecAction \"msg:'e\u0301 \U0001F469\u200d\U0001F4BB x'\"",
+				"                     ^",
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - tabs expand to the next tab stop",
+			contents: "SecAction\t\"id:1\"",
+			offset:   10,
+			distance: 6,
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 10:",
+				`SecAction   "id:1"`,
+				`            ^^^^^^`,
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - control characters are replaced",
+			contents: "SecMarker \x1b[0m",
+			offset:   10,
+			distance: 4,
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 10:",
+				"SecMarker \uFFFD[0m",
+				"          ^^^^",
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - lines wrap at the given width",
+			contents: `SecRule ARGS "@rx 日本語日本語" "id:1"`,
+			offset:   13,
+			distance: 24,
+			options:  RenderOptions{Width: 20},
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 1, column 13:",
+				`SecRule ARGS "@rx 日`,
+				`             ^^^^ ^^`,
+				`    本語日本語" "id:`,
+				`    ^^^^^^^^^^^`,
+				`    1"`,
+				"",
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - context lines around the error",
+			contents: "# first\n# second\nSecMarker END\n# third\n# fourth\n",
+			offset:   27,
+			distance: 3,
+			options:  RenderOptions{Context: 1},
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 3, column 10:",
+				"# second",
+				"SecMarker END",
+				"          ^^^",
+				"# third",
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - context lines stop at the end of the file",
+			contents: "# first\nSecMarker END\n",
+			offset:   18,
+			distance: 3,
+			options:  RenderOptions{Context: 2},
+			want: joinString(
+				"",
+				"Error: wrong",
+				"line 2, column 10:",
+				"# first",
+				"SecMarker END",
+				"          ^^^",
+				"",
+			),
+		},
+		{
+			name:     "POSITIVE - level and carets highlighted with colors",
+			contents: "SecMarker END",
+			offset:   10,
+			distance: 3,
+			options:  RenderOptions{Color: true},
+			want: joinString(
+				"",
+				"\x1b[1m\x1b[31mError\x1b[0m\x1b[1m: wrong\x1b[0m",
+				"line 1, column 10:",
+				"SecMarker END",
+				"\x1b[31m          ^^^\x1b[0m",
+				"",
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &LinterError{
+				Message:    "wrong",
+				ParseLevel: ParseLevelError,
+				Offset:     tt.offset,
+				Distance:   tt.distance,
+				Contents:   tt.contents,
+			}
+
+			if diff := deep.Equal(e.Render(tt.options), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package parse
+
+import "strings"
+
+// all operations of the setvar action
+const (
+	SetvarOperationSet       = "="
+	SetvarOperationIncrement = "=+"
+	SetvarOperationDecrement = "=-"
+	SetvarOperationDelete    = "!"
+)
+
+// represents the value of a setvar action.
+// Examples:
+//   - tx.inbound_anomaly_score_pl1=+%{tx.critical_anomaly_score}
+//   - !tx.allowed_methods
+type Setvar struct {
+	// collection holding the variable, ex. "tx"
+	Collection string
+
+	// key of the variable within the collection,
+	// ex. "inbound_anomaly_score_pl1"
+	Key string
+
+	// operation applied to the variable, ex. "=+"
+	Operation string
+
+	// value used by the operation, ex. "%{tx.critical_anomaly_score}"
+	Value string
+
+	// offset of the collection within the entire file
+	Offset int
+
+	// offset of the key within the entire file
+	KeyOffset int
+
+	// offset of the value within the entire file
+	ValueOffset int
+}
+
+// parses the value of the given setvar action.
+// Contents represents the entire read content the action came from.
+func ParseSetvar(contents []byte, action *Action) (*Setvar, error) {
+	setvar := &Setvar{
+		Operation: SetvarOperationSet,
+		Offset:    action.ValueOffset,
+	}
+
+	expression := action.Value
+
+	if strings.HasPrefix(expression, SetvarOperationDelete) {
+		setvar.Operation = SetvarOperationDelete
+		expression = expression[1:]
+		setvar.Offset++
+	}
+
+	variable, value, hasValue := strings.Cut(expression, "=")
+
+	collection, key, found := strings.Cut(variable, ".")
+	if !found || collection == "" || key == "" {
+		return nil, &LinterError{
+			Kind:       ErrInvalidSetvar,
+			Message:    "expected setvar variable as COLLECTION.key",
+			ParseLevel: ParseLevelError,
+			Offset:     setvar.Offset,
+			Distance:   max(len(variable), 1),
+			Contents:   string(contents),
+		}
+	}
+
+	setvar.Collection = collection
+	setvar.Key = key
+	setvar.KeyOffset = setvar.Offset + len(collection) + 1
+	setvar.ValueOffset = setvar.Offset + len(variable)
+
+	if !hasValue {
+		return setvar, nil
+	}
+
+	if setvar.Operation == SetvarOperationDelete {
+		return nil, &LinterError{
+			Kind:       ErrInvalidSetvar,
+			Message:    "setvar cannot delete and assign a variable at once",
+			ParseLevel: ParseLevelError,
+			Offset:     action.ValueOffset,
+			Distance:   len(action.Value),
+			Contents:   string(contents),
+		}
+	}
+
+	setvar.ValueOffset++
+
+	switch {
+	case strings.HasPrefix(value, "+"):
+		setvar.Operation = SetvarOperationIncrement
+	case strings.HasPrefix(value, "-"):
+		setvar.Operation = SetvarOperationDecrement
+	}
+
+	if setvar.Operation != SetvarOperationSet {
+		value = value[1:]
+		setvar.ValueOffset++
+	}
+
+	setvar.Value = value
+
+	return setvar, nil
+}
@@ -0,0 +1,103 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseSetvar(t *testing.T) {
+	// builds the setvar action of the given value found at offset 18
+	action := func(value string) *Action {
+		return &Action{
+			Name:        "setvar",
+			Value:       value,
+			Lexeme:      "setvar:'" + value + "'",
+			Offset:      10,
+			ValueOffset: 18,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		action  *Action
+		want    *Setvar
+		wantErr bool
+	}{
+		{
+			name:   "POSITIVE - assignment",
+			action: action("tx.score=5"),
+			want: &Setvar{
+				Collection:  "tx",
+				Key:         "score",
+				Operation:   SetvarOperationSet,
+				Value:       "5",
+				Offset:      18,
+				KeyOffset:   21,
+				ValueOffset: 27,
+			},
+		},
+		{
+			name:   "POSITIVE - increment by macro",
+			action: action("tx.score=+%{tx.critical_anomaly_score}"),
+			want: &Setvar{
+				Collection:  "tx",
+				Key:         "score",
+				Operation:   SetvarOperationIncrement,
+				Value:       "%{tx.critical_anomaly_score}",
+				Offset:      18,
+				KeyOffset:   21,
+				ValueOffset: 28,
+			},
+		},
+		{
+			name:   "POSITIVE - decrement",
+			action: action("ip.score=-1"),
+			want: &Setvar{
+				Collection:  "ip",
+				Key:         "score",
+				Operation:   SetvarOperationDecrement,
+				Value:       "1",
+				Offset:      18,
+				KeyOffset:   21,
+				ValueOffset: 28,
+			},
+		},
+		{
+			name:   "POSITIVE - deletion",
+			action: action("!tx.score"),
+			want: &Setvar{
+				Collection:  "tx",
+				Key:         "score",
+				Operation:   SetvarOperationDelete,
+				Offset:      19,
+				KeyOffset:   22,
+				ValueOffset: 27,
+			},
+		},
+		{
+			name:    "NEGATIVE - missing collection",
+			action:  action("score=5"),
+			wantErr: true,
+		},
+		{
+			name:    "NEGATIVE - deletion with value",
+			action:  action("!tx.score=5"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contents := []byte(`SecAction "` + tt.action.Lexeme + `"`)
+
+			got, err := ParseSetvar(contents, tt.action)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSetvar() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package parse
+
+import "strings"
+
+// kinds of trivia found between tokens
+const (
+	// spaces and tabs
+	TriviaWhitespace = iota
+	// a line break, "\n" or "\r\n"
+	TriviaNewline
+	// a comment up to, but excluding, the end of its line
+	TriviaComment
+	// a backslash continuing the directive on the next line,
+	// including the line break
+	TriviaContinuation
+)
+
+// kinds of tokens within a directive
+const (
+	// the name of the directive
+	TokenDirective = iota
+	// an option of the directive
+	TokenOption
+)
+
+// represents text without meaning to Coraza, such as
+// whitespace, comments and line continuations
+type Trivia struct {
+	// one of the Trivia kinds
+	Kind int
+
+	// text of the trivia as written in the file
+	Text string
+
+	// offset of the trivia within the entire file
+	Offset int
+}
+
+// represents a meaningful piece of text within a directive
+// along with the trivia written before it
+type SyntaxToken struct {
+	// one of the Token kinds
+	Kind int
+
+	// trivia written between the previous token and this one
+	Leading []*Trivia
+
+	// text of the token, changing it edits the file
+	// when the tree is written back with Bytes
+	Text string
+
+	// offset of the token within the entire file
+	Offset int
+}
+
+// represents a directive within the syntax tree
+type SyntaxDirective struct {
+	// the parsed directive
+	Directive *Directive
+
+	// name and option tokens of the directive, in order
+	Tokens []*SyntaxToken
+}
+
+// represents a lossless syntax tree of a file, where every
+// byte of the file belongs to a token or to trivia
+type SyntaxTree struct {
+	// directives of the file, in order
+	Directives []*SyntaxDirective
+
+	// trivia written after the last directive
+	Trailing []*Trivia
+}
+
+// Returns the contents of the file the tree represents,
+// including edits made to the text of its tokens and trivia
+func (t *SyntaxTree) Bytes() []byte {
+	var out strings.Builder
+
+	for _, directive := range t.Directives {
+		for _, token := range directive.Tokens {
+			writeTrivia(&out, token.Leading)
+			out.WriteString(token.Text)
+		}
+	}
+
+	writeTrivia(&out, t.Trailing)
+
+	return []byte(out.String())
+}
+
+// writes the text of the trivia
+func writeTrivia(out *strings.Builder, trivia []*Trivia) {
+	for _, t := range trivia {
+		out.WriteString(t.Text)
+	}
+}
+
+// Parses the lossless syntax tree of the given contents, attaching
+// comments, whitespace and line continuations as trivia
+func ParseSyntaxTree(contents []byte) (*SyntaxTree, error) {
+	directives, err := ParseDirectives(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tree   = &SyntaxTree{Directives: make([]*SyntaxDirective, 0, len(directives))}
+		text   = string(contents)
+		offset = 0
+	)
+
+	for _, directive := range directives {
+		node := &SyntaxDirective{
+			Directive: directive,
+			Tokens:    make([]*SyntaxToken, 0, len(directive.Options)+1),
+		}
+
+		node.Tokens = append(node.Tokens, &SyntaxToken{
+			Kind:    TokenDirective,
+			Leading: splitTrivia(text[offset:directive.Offset], offset),
+			Text:    directive.Lexeme,
+			Offset:  directive.Offset,
+		})
+
+		offset = directive.Offset + len(directive.Lexeme)
+
+		for _, option := range directive.Options {
+			node.Tokens = append(node.Tokens, &SyntaxToken{
+				Kind:    TokenOption,
+				Leading: splitTrivia(text[offset:option.Offset], offset),
+				Text:    option.Lexeme,
+				Offset:  option.Offset,
+			})
+
+			offset = option.Offset + option.Len()
+		}
+
+		tree.Directives = append(tree.Directives, node)
+	}
+
+	tree.Trailing = splitTrivia(text[offset:], offset)
+
+	return tree, nil
+}
+
+// splits text found between tokens at the given offset into trivia
+func splitTrivia(text string, offset int) []*Trivia {
+	var trivia []*Trivia
+
+	for i := 0; i < len(text); {
+		var (
+			rest   = text[i:]
+			kind   int
+			length int
+		)
+
+		switch {
+		case strings.HasPrefix(rest, "\r\n"):
+			kind, length = TriviaNewline, 2
+		case rest[0] == '\n':
+			kind, length = TriviaNewline, 1
+		case strings.HasPrefix(rest, "\\\r\n"):
+			kind, length = TriviaContinuation, 3
+		case strings.HasPrefix(rest, "\\\n"):
+			kind, length = TriviaContinuation, 2
+		case rest[0] == '#':
+			kind, length = TriviaComment, lineEnd(rest)
+		default:
+			kind, length = TriviaWhitespace, whitespaceEnd(rest)
+		}
+
+		trivia = append(trivia, &Trivia{
+			Kind:   kind,
+			Text:   rest[:length],
+			Offset: offset + i,
+		})
+
+		i += length
+	}
+
+	return trivia
+}
+
+// returns the index of the line break ending the first line
+// of the text, or the length of the text
+func lineEnd(text string) int {
+	end := strings.IndexByte(text, '\n')
+	if end == -1 {
+		return len(text)
+	}
+
+	if end > 0 && text[end-1] == '\r' {
+		end--
+	}
+
+	return end
+}
+
+// returns the length of the whitespace starting the text,
+// which is at least a single byte
+func whitespaceEnd(text string) int {
+	for i := 1; i < len(text); i++ {
+		rest := text[i:]
+
+		if rest[0] == '\n' ||
+			rest[0] == '#' ||
+			strings.HasPrefix(rest, "\r\n") ||
+			strings.HasPrefix(rest, "\\\n") ||
+			strings.HasPrefix(rest, "\\\r\n") {
+			return i
+		}
+	}
+
+	return len(text)
+}
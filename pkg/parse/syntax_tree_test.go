@@ -0,0 +1,118 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseSyntaxTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *SyntaxTree
+		wantErr bool
+	}{
+		{
+			name:    "POSITIVE - comments, blank lines and continuations",
+			content: "# header\n\nSecRule ARGS \\\n  \"@rx a\"\n",
+			want: &SyntaxTree{
+				Directives: []*SyntaxDirective{
+					{
+						Tokens: []*SyntaxToken{
+							{
+								Kind: TokenDirective,
+								Leading: []*Trivia{
+									{Kind: TriviaComment, Text: "# header", Offset: 0},
+									{Kind: TriviaNewline, Text: "\n", Offset: 8},
+									{Kind: TriviaNewline, Text: "\n", Offset: 9},
+								},
+								Text:   "SecRule",
+								Offset: 10,
+							},
+							{
+								Kind: TokenOption,
+								Leading: []*Trivia{
+									{Kind: TriviaWhitespace, Text: " ", Offset: 17},
+								},
+								Text:   "ARGS",
+								Offset: 18,
+							},
+							{
+								Kind: TokenOption,
+								Leading: []*Trivia{
+									{Kind: TriviaWhitespace, Text: " ", Offset: 22},
+									{Kind: TriviaContinuation, Text: "\\\n", Offset: 23},
+									{Kind: TriviaWhitespace, Text: "  ", Offset: 25},
+								},
+								Text:   `"@rx a"`,
+								Offset: 27,
+							},
+						},
+					},
+				},
+				Trailing: []*Trivia{
+					{Kind: TriviaNewline, Text: "\n", Offset: 34},
+				},
+			},
+		},
+		{
+			name:    "POSITIVE - only comments",
+			content: "# one\r\n\t# two",
+			want: &SyntaxTree{
+				Directives: []*SyntaxDirective{},
+				Trailing: []*Trivia{
+					{Kind: TriviaComment, Text: "# one", Offset: 0},
+					{Kind: TriviaNewline, Text: "\r\n", Offset: 5},
+					{Kind: TriviaWhitespace, Text: "\t", Offset: 7},
+					{Kind: TriviaComment, Text: "# two", Offset: 8},
+				},
+			},
+		},
+		{
+			name:    "NEGATIVE - invalid directive",
+			content: `SecRule "unterminated`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSyntaxTree([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSyntaxTree() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// directives are covered by TestParseDirectives
+			for _, directive := range got.Directives {
+				directive.Directive = nil
+			}
+
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			if string(got.Bytes()) != tt.content {
+				t.Errorf("Bytes() = %q, want %q", got.Bytes(), tt.content)
+			}
+		})
+	}
+}
+
+func TestSyntaxTree_Bytes(t *testing.T) {
+	content := "# keep me\nSecRule ARGS \\\n    \"@rx a\" \\\n    \"id:1,deny\"\n\n# and me\n"
+
+	tree, err := ParseSyntaxTree([]byte(content))
+	if err != nil {
+		t.Fatalf("could not parse test contents: %v", err)
+	}
+
+	tree.Directives[0].Tokens[3].Text = `"id:1,pass"`
+
+	want := "# keep me\nSecRule ARGS \\\n    \"@rx a\" \\\n    \"id:1,pass\"\n\n# and me\n"
+	if diff := deep.Equal(string(tree.Bytes()), want); diff != nil {
+		t.Error(diff)
+	}
+}
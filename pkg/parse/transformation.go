@@ -0,0 +1,82 @@
+package parse
+
+// all transformation names supported by Coraza
+const (
+	TransformationBase64Decode       = "base64Decode"
+	TransformationBase64DecodeExt    = "base64DecodeExt"
+	TransformationBase64Encode       = "base64Encode"
+	TransformationCmdLine            = "cmdLine"
+	TransformationCompressWhitespace = "compressWhitespace"
+	TransformationCSSDecode          = "cssDecode"
+	TransformationEscapeSeqDecode    = "escapeSeqDecode"
+	TransformationHexDecode          = "hexDecode"
+	TransformationHexEncode          = "hexEncode"
+	TransformationHTMLEntityDecode   = "htmlEntityDecode"
+	TransformationJSDecode           = "jsDecode"
+	TransformationLength             = "length"
+	TransformationLowercase          = "lowercase"
+	TransformationMD5                = "md5"
+	TransformationNone               = "none"
+	TransformationNormalisePath      = "normalisePath"
+	TransformationNormalisePathWin   = "normalisePathWin"
+	TransformationNormalizePath      = "normalizePath"
+	TransformationNormalizePathWin   = "normalizePathWin"
+	TransformationRemoveComments     = "removeComments"
+	TransformationRemoveCommentsChar = "removeCommentsChar"
+	TransformationRemoveNulls        = "removeNulls"
+	TransformationRemoveWhitespace   = "removeWhitespace"
+	TransformationReplaceComments    = "replaceComments"
+	TransformationReplaceNulls       = "replaceNulls"
+	TransformationSHA1               = "sha1"
+	TransformationSQLHexDecode       = "sqlHexDecode"
+	TransformationTrim               = "trim"
+	TransformationTrimLeft           = "trimLeft"
+	TransformationTrimRight          = "trimRight"
+	TransformationUppercase          = "uppercase"
+	TransformationURLDecode          = "urlDecode"
+	TransformationURLDecodeUni       = "urlDecodeUni"
+	TransformationURLEncode          = "urlEncode"
+	TransformationUTF8ToUnicode      = "utf8toUnicode"
+)
+
+// returns all transformation names supported by Coraza
+// as a string slice.
+func TransformationNames() []string {
+	return []string{
+		TransformationBase64Decode,
+		TransformationBase64DecodeExt,
+		TransformationBase64Encode,
+		TransformationCmdLine,
+		TransformationCompressWhitespace,
+		TransformationCSSDecode,
+		TransformationEscapeSeqDecode,
+		TransformationHexDecode,
+		TransformationHexEncode,
+		TransformationHTMLEntityDecode,
+		TransformationJSDecode,
+		TransformationLength,
+		TransformationLowercase,
+		TransformationMD5,
+		TransformationNone,
+		TransformationNormalisePath,
+		TransformationNormalisePathWin,
+		TransformationNormalizePath,
+		TransformationNormalizePathWin,
+		TransformationRemoveComments,
+		TransformationRemoveCommentsChar,
+		TransformationRemoveNulls,
+		TransformationRemoveWhitespace,
+		TransformationReplaceComments,
+		TransformationReplaceNulls,
+		TransformationSHA1,
+		TransformationSQLHexDecode,
+		TransformationTrim,
+		TransformationTrimLeft,
+		TransformationTrimRight,
+		TransformationUppercase,
+		TransformationURLDecode,
+		TransformationURLDecodeUni,
+		TransformationURLEncode,
+		TransformationUTF8ToUnicode,
+	}
+}
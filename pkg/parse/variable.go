@@ -0,0 +1,382 @@
+package parse
+
+import "strings"
+
+// all variable names supported by Coraza
+const (
+	VariableArgs                          = "ARGS"
+	VariableArgsCombinedSize              = "ARGS_COMBINED_SIZE"
+	VariableArgsGet                       = "ARGS_GET"
+	VariableArgsGetNames                  = "ARGS_GET_NAMES"
+	VariableArgsNames                     = "ARGS_NAMES"
+	VariableArgsPath                      = "ARGS_PATH"
+	VariableArgsPost                      = "ARGS_POST"
+	VariableArgsPostNames                 = "ARGS_POST_NAMES"
+	VariableAuthType                      = "AUTH_TYPE"
+	VariableDuration                      = "DURATION"
+	VariableEnv                           = "ENV"
+	VariableFiles                         = "FILES"
+	VariableFilesCombinedSize             = "FILES_COMBINED_SIZE"
+	VariableFilesNames                    = "FILES_NAMES"
+	VariableFilesSizes                    = "FILES_SIZES"
+	VariableFilesTmpNames                 = "FILES_TMPNAMES"
+	VariableFilesTmpContent               = "FILES_TMP_CONTENT"
+	VariableFullRequest                   = "FULL_REQUEST"
+	VariableFullRequestLength             = "FULL_REQUEST_LENGTH"
+	VariableGeo                           = "GEO"
+	VariableGlobal                        = "GLOBAL"
+	VariableHighestSeverity               = "HIGHEST_SEVERITY"
+	VariableInboundDataError              = "INBOUND_DATA_ERROR"
+	VariableIP                            = "IP"
+	VariableJSON                          = "JSON"
+	VariableMatchedVar                    = "MATCHED_VAR"
+	VariableMatchedVars                   = "MATCHED_VARS"
+	VariableMatchedVarsNames              = "MATCHED_VARS_NAMES"
+	VariableMatchedVarName                = "MATCHED_VAR_NAME"
+	VariableMultipartBoundaryQuoted       = "MULTIPART_BOUNDARY_QUOTED"
+	VariableMultipartBoundaryWhitespace   = "MULTIPART_BOUNDARY_WHITESPACE"
+	VariableMultipartCRLFLFLines          = "MULTIPART_CRLF_LF_LINES"
+	VariableMultipartDataAfter            = "MULTIPART_DATA_AFTER"
+	VariableMultipartDataBefore           = "MULTIPART_DATA_BEFORE"
+	VariableMultipartFilename             = "MULTIPART_FILENAME"
+	VariableMultipartFileLimitExceeded    = "MULTIPART_FILE_LIMIT_EXCEEDED"
+	VariableMultipartHeaderFolding        = "MULTIPART_HEADER_FOLDING"
+	VariableMultipartInvalidHeaderFolding = "MULTIPART_INVALID_HEADER_FOLDING"
+	VariableMultipartInvalidPart          = "MULTIPART_INVALID_PART"
+	VariableMultipartInvalidQuoting       = "MULTIPART_INVALID_QUOTING"
+	VariableMultipartLFLine               = "MULTIPART_LF_LINE"
+	VariableMultipartMissingSemicolon     = "MULTIPART_MISSING_SEMICOLON"
+	VariableMultipartName                 = "MULTIPART_NAME"
+	VariableMultipartPartHeaders          = "MULTIPART_PART_HEADERS"
+	VariableMultipartStrictError          = "MULTIPART_STRICT_ERROR"
+	VariableMultipartUnmatchedBoundary    = "MULTIPART_UNMATCHED_BOUNDARY"
+	VariableOutboundDataError             = "OUTBOUND_DATA_ERROR"
+	VariableQueryString                   = "QUERY_STRING"
+	VariableRemoteAddr                    = "REMOTE_ADDR"
+	VariableRemoteHost                    = "REMOTE_HOST"
+	VariableRemotePort                    = "REMOTE_PORT"
+	VariableRemoteUser                    = "REMOTE_USER"
+	VariableReqBodyError                  = "REQBODY_ERROR"
+	VariableReqBodyErrorMsg               = "REQBODY_ERROR_MSG"
+	VariableReqBodyProcessor              = "REQBODY_PROCESSOR"
+	VariableReqBodyProcessorError         = "REQBODY_PROCESSOR_ERROR"
+	VariableReqBodyProcessorErrorMsg      = "REQBODY_PROCESSOR_ERROR_MSG"
+	VariableRequestBasename               = "REQUEST_BASENAME"
+	VariableRequestBody                   = "REQUEST_BODY"
+	VariableRequestBodyLength             = "REQUEST_BODY_LENGTH"
+	VariableRequestCookies                = "REQUEST_COOKIES"
+	VariableRequestCookiesNames           = "REQUEST_COOKIES_NAMES"
+	VariableRequestFilename               = "REQUEST_FILENAME"
+	VariableRequestHeaders                = "REQUEST_HEADERS"
+	VariableRequestHeadersNames           = "REQUEST_HEADERS_NAMES"
+	VariableRequestLine                   = "REQUEST_LINE"
+	VariableRequestMethod                 = "REQUEST_METHOD"
+	VariableRequestProtocol               = "REQUEST_PROTOCOL"
+	VariableRequestURI                    = "REQUEST_URI"
+	VariableRequestURIRaw                 = "REQUEST_URI_RAW"
+	VariableResource                      = "RESOURCE"
+	VariableResponseArgs                  = "RESPONSE_ARGS"
+	VariableResponseBody                  = "RESPONSE_BODY"
+	VariableResponseContentLength         = "RESPONSE_CONTENT_LENGTH"
+	VariableResponseContentType           = "RESPONSE_CONTENT_TYPE"
+	VariableResponseHeaders               = "RESPONSE_HEADERS"
+	VariableResponseHeadersNames          = "RESPONSE_HEADERS_NAMES"
+	VariableResponseProtocol              = "RESPONSE_PROTOCOL"
+	VariableResponseStatus                = "RESPONSE_STATUS"
+	VariableResponseXML                   = "RESPONSE_XML"
+	VariableRule                          = "RULE"
+	VariableServerAddr                    = "SERVER_ADDR"
+	VariableServerName                    = "SERVER_NAME"
+	VariableServerPort                    = "SERVER_PORT"
+	VariableSession                       = "SESSION"
+	VariableSessionID                     = "SESSIONID"
+	VariableStatusLine                    = "STATUS_LINE"
+	VariableTime                          = "TIME"
+	VariableTimeDay                       = "TIME_DAY"
+	VariableTimeEpoch                     = "TIME_EPOCH"
+	VariableTimeHour                      = "TIME_HOUR"
+	VariableTimeMin                       = "TIME_MIN"
+	VariableTimeMon                       = "TIME_MON"
+	VariableTimeSec                       = "TIME_SEC"
+	VariableTimeWDay                      = "TIME_WDAY"
+	VariableTimeYear                      = "TIME_YEAR"
+	VariableTX                            = "TX"
+	VariableUniqueID                      = "UNIQUE_ID"
+	VariableURLEncodedError               = "URLENCODED_ERROR"
+	VariableUser                          = "USER"
+	VariableUserID                        = "USERID"
+	VariableWebserverErrorLog             = "WEBSERVER_ERROR_LOG"
+	VariableXML                           = "XML"
+)
+
+// returns all variable names supported by Coraza as a string slice.
+func VariableNames() []string {
+	return []string{
+		VariableArgs,
+		VariableArgsCombinedSize,
+		VariableArgsGet,
+		VariableArgsGetNames,
+		VariableArgsNames,
+		VariableArgsPath,
+		VariableArgsPost,
+		VariableArgsPostNames,
+		VariableAuthType,
+		VariableDuration,
+		VariableEnv,
+		VariableFiles,
+		VariableFilesCombinedSize,
+		VariableFilesNames,
+		VariableFilesSizes,
+		VariableFilesTmpNames,
+		VariableFilesTmpContent,
+		VariableFullRequest,
+		VariableFullRequestLength,
+		VariableGeo,
+		VariableGlobal,
+		VariableHighestSeverity,
+		VariableInboundDataError,
+		VariableIP,
+		VariableJSON,
+		VariableMatchedVar,
+		VariableMatchedVars,
+		VariableMatchedVarsNames,
+		VariableMatchedVarName,
+		VariableMultipartBoundaryQuoted,
+		VariableMultipartBoundaryWhitespace,
+		VariableMultipartCRLFLFLines,
+		VariableMultipartDataAfter,
+		VariableMultipartDataBefore,
+		VariableMultipartFilename,
+		VariableMultipartFileLimitExceeded,
+		VariableMultipartHeaderFolding,
+		VariableMultipartInvalidHeaderFolding,
+		VariableMultipartInvalidPart,
+		VariableMultipartInvalidQuoting,
+		VariableMultipartLFLine,
+		VariableMultipartMissingSemicolon,
+		VariableMultipartName,
+		VariableMultipartPartHeaders,
+		VariableMultipartStrictError,
+		VariableMultipartUnmatchedBoundary,
+		VariableOutboundDataError,
+		VariableQueryString,
+		VariableRemoteAddr,
+		VariableRemoteHost,
+		VariableRemotePort,
+		VariableRemoteUser,
+		VariableReqBodyError,
+		VariableReqBodyErrorMsg,
+		VariableReqBodyProcessor,
+		VariableReqBodyProcessorError,
+		VariableReqBodyProcessorErrorMsg,
+		VariableRequestBasename,
+		VariableRequestBody,
+		VariableRequestBodyLength,
+		VariableRequestCookies,
+		VariableRequestCookiesNames,
+		VariableRequestFilename,
+		VariableRequestHeaders,
+		VariableRequestHeadersNames,
+		VariableRequestLine,
+		VariableRequestMethod,
+		VariableRequestProtocol,
+		VariableRequestURI,
+		VariableRequestURIRaw,
+		VariableResource,
+		VariableResponseArgs,
+		VariableResponseBody,
+		VariableResponseContentLength,
+		VariableResponseContentType,
+		VariableResponseHeaders,
+		VariableResponseHeadersNames,
+		VariableResponseProtocol,
+		VariableResponseStatus,
+		VariableResponseXML,
+		VariableRule,
+		VariableServerAddr,
+		VariableServerName,
+		VariableServerPort,
+		VariableSession,
+		VariableSessionID,
+		VariableStatusLine,
+		VariableTime,
+		VariableTimeDay,
+		VariableTimeEpoch,
+		VariableTimeHour,
+		VariableTimeMin,
+		VariableTimeMon,
+		VariableTimeSec,
+		VariableTimeWDay,
+		VariableTimeYear,
+		VariableTX,
+		VariableUniqueID,
+		VariableURLEncodedError,
+		VariableUser,
+		VariableUserID,
+		VariableWebserverErrorLog,
+		VariableXML,
+	}
+}
+
+// represents a single variable within the target list of a SecRule.
+// Examples:
+//   - ARGS
+//   - !REQUEST_HEADERS:Referer
+//   - &TX:/^header_name_/
+type Variable struct {
+	// name of the variable or collection, ex. "REQUEST_HEADERS"
+	Name string
+
+	// key selecting members of the collection, empty if the
+	// whole variable is selected, ex. "Referer" or "/^header_/"
+	Key string
+
+	// reports whether the members of the variable are counted
+	Count bool
+
+	// reports whether the variable is excluded from the targets
+	Exclude bool
+
+	// raw variable text as written in the file
+	Lexeme string
+
+	// offset of the variable within the entire file
+	Offset int
+}
+
+// Returns the length of the variable lexeme
+func (v *Variable) Len() int {
+	return len(v.Lexeme)
+}
+
+// reports whether the key of the variable is a regular expression
+func (v *Variable) KeyIsRegex() bool {
+	return len(v.Key) >= 2 &&
+		v.Key[0] == '/' &&
+		v.Key[len(v.Key)-1] == '/' &&
+		!strings.EqualFold(v.Name, "XML")
+}
+
+// parses the pipe separated target list held by the given option.
+// Contents represents the entire read content the option came from.
+func ParseVariables(contents []byte, option *Option) ([]*Variable, error) {
+	body := option.Lexeme
+	offset := option.Offset
+
+	if len(body) >= 2 && body[0] == '"' && body[len(body)-1] == '"' {
+		body = body[1 : len(body)-1]
+		offset++
+	}
+
+	variables := make([]*Variable, 0, strings.Count(body, "|")+1)
+
+	for start := 0; start <= len(body); {
+		end := variableEnd(body, start)
+
+		variable := parseVariable(body[start:end], offset+start)
+		if variable == nil {
+			return nil, &LinterError{
+				Kind:       ErrInvalidVariable,
+				Message:    "expected variable name in target list",
+				ParseLevel: ParseLevelError,
+				Offset:     offset + start,
+				Distance:   max(end-start, 1),
+				Contents:   string(contents),
+			}
+		}
+
+		variables = append(variables, variable)
+
+		start = end + 1
+	}
+
+	return variables, nil
+}
+
+// returns the index of the pipe ending the variable
+// starting at the given index, or the end of the body
+func variableEnd(body string, start int) int {
+	for i := start; i < len(body); i++ {
+		switch body[i] {
+		case '|':
+			return i
+		case ':':
+			return keyEnd(body, start, i)
+		}
+	}
+
+	return len(body)
+}
+
+// returns the index of the pipe ending the key which follows
+// the colon at the given index, or the end of the body.
+// Regular expression and quoted keys may contain pipes.
+func keyEnd(body string, start, colon int) int {
+	var (
+		i       = colon + 1
+		closing byte
+	)
+
+	if i < len(body) {
+		name := strings.TrimLeft(body[start:colon], "!&")
+
+		switch {
+		case body[i] == '\'':
+			closing = '\''
+		case body[i] == '/' && !strings.EqualFold(name, "XML"):
+			closing = '/'
+		}
+	}
+
+	if closing != 0 {
+		for i++; i < len(body) && body[i] != closing; i++ {
+			if body[i] == '\\' {
+				i++
+			}
+		}
+	}
+
+	for ; i < len(body); i++ {
+		if body[i] == '|' {
+			return i
+		}
+	}
+
+	return len(body)
+}
+
+// parses a single raw variable found at the given offset,
+// returns nil if the variable has no name
+func parseVariable(raw string, offset int) *Variable {
+	start, end := trimContinuation(raw)
+	raw = raw[start:end]
+	offset += start
+
+	variable := &Variable{
+		Lexeme: raw,
+		Offset: offset,
+	}
+
+	name := raw
+
+	for len(name) > 0 && (name[0] == '!' || name[0] == '&') {
+		variable.Exclude = variable.Exclude || name[0] == '!'
+		variable.Count = variable.Count || name[0] == '&'
+		name = name[1:]
+	}
+
+	name, key, _ := strings.Cut(name, ":")
+
+	if len(key) >= 2 && key[0] == '\'' && key[len(key)-1] == '\'' {
+		key = key[1 : len(key)-1]
+	}
+
+	if name == "" {
+		return nil
+	}
+
+	variable.Name = name
+	variable.Key = key
+
+	return variable
+}
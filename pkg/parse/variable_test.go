@@ -0,0 +1,116 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseVariables(t *testing.T) {
+	type args struct {
+		contents []byte
+		option   *Option
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*Variable
+		wantErr bool
+	}{
+		{
+			name: "POSITIVE - single variable",
+			args: args{
+				contents: []byte(`SecRule ARGS "@rx foo"`),
+				option: &Option{
+					Lexeme: "ARGS",
+					Offset: 8,
+				},
+			},
+			want: []*Variable{
+				{
+					Name:   "ARGS",
+					Lexeme: "ARGS",
+					Offset: 8,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - excluded and counted variables with keys",
+			args: args{
+				contents: []byte(`SecRule REQUEST_HEADERS|!REQUEST_HEADERS:Referer|&TX:score "@rx foo"`),
+				option: &Option{
+					Lexeme: "REQUEST_HEADERS|!REQUEST_HEADERS:Referer|&TX:score",
+					Offset: 8,
+				},
+			},
+			want: []*Variable{
+				{
+					Name:   "REQUEST_HEADERS",
+					Lexeme: "REQUEST_HEADERS",
+					Offset: 8,
+				},
+				{
+					Name:    "REQUEST_HEADERS",
+					Key:     "Referer",
+					Exclude: true,
+					Lexeme:  "!REQUEST_HEADERS:Referer",
+					Offset:  24,
+				},
+				{
+					Name:   "TX",
+					Key:    "score",
+					Count:  true,
+					Lexeme: "&TX:score",
+					Offset: 49,
+				},
+			},
+		},
+		{
+			name: "POSITIVE - regular expression key containing a pipe",
+			args: args{
+				contents: []byte(`SecRule TX:/^(a|b)$/|XML:/* "@rx foo"`),
+				option: &Option{
+					Lexeme: "TX:/^(a|b)$/|XML:/*",
+					Offset: 8,
+				},
+			},
+			want: []*Variable{
+				{
+					Name:   "TX",
+					Key:    "/^(a|b)$/",
+					Lexeme: "TX:/^(a|b)$/",
+					Offset: 8,
+				},
+				{
+					Name:   "XML",
+					Key:    "/*",
+					Lexeme: "XML:/*",
+					Offset: 21,
+				},
+			},
+		},
+		{
+			name: "NEGATIVE - empty variable",
+			args: args{
+				contents: []byte(`SecRule ARGS||TX "@rx foo"`),
+				option: &Option{
+					Lexeme: "ARGS||TX",
+					Offset: 8,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVariables(tt.args.contents, tt.args.option)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseVariables() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
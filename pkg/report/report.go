@@ -0,0 +1,265 @@
+// Package report writes the findings of the linter, grouped
+// for people reading a terminal or one per line for tools
+package report
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+)
+
+// formats findings are written in
+const (
+	// findings grouped by file with their underlined lines,
+	// followed by a summary of the findings of every file
+	FormatPretty = "pretty"
+	// a single line per finding, for grepping
+	FormatCompact = "compact"
+	// workflow commands annotating the lines of
+	// the findings in GitHub Actions
+	FormatGitHub = "github"
+)
+
+// ANSI escape sequences of highlighted text
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+)
+
+// returns the names of all formats
+func Formats() []string {
+	return []string{FormatPretty, FormatCompact, FormatGitHub}
+}
+
+// Writes the findings in the named format, rendering
+// their lines with the given options
+func Write(w io.Writer, format string, findings []*parse.LinterError, options parse.RenderOptions) error {
+	switch format {
+	case FormatPretty:
+		return Pretty(w, findings, options)
+	case FormatCompact:
+		return Compact(w, findings)
+	case FormatGitHub:
+		return GitHub(w, findings)
+	}
+
+	return fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(Formats(), ", "))
+}
+
+// represents the findings of a single file
+type group struct {
+	// name of the file
+	name string
+
+	// findings of the file, in order of offset
+	findings []*parse.LinterError
+}
+
+// returns the number of findings of the group at the given level
+func (g *group) count(level int) int {
+	count := 0
+
+	for _, finding := range g.findings {
+		if finding.ParseLevel == level {
+			count++
+		}
+	}
+
+	return count
+}
+
+// groups the findings by file, in order of the first finding
+// of every file, keeping the order findings are reported in,
+// which analyze.Analyze sorts by file load order and offset
+func groupByFile(findings []*parse.LinterError) []*group {
+	var (
+		groups []*group
+		byName = map[string]*group{}
+	)
+
+	for _, finding := range findings {
+		g, ok := byName[finding.File]
+		if !ok {
+			g = &group{name: finding.File}
+			byName[finding.File] = g
+			groups = append(groups, g)
+		}
+
+		g.findings = append(g.findings, finding)
+	}
+
+	return groups
+}
+
+// Writes the findings grouped by file under the name of the file,
+// followed by a table counting the findings of every level
+func Pretty(w io.Writer, findings []*parse.LinterError, options parse.RenderOptions) error {
+	var (
+		out    strings.Builder
+		groups = groupByFile(findings)
+	)
+
+	for _, g := range groups {
+		out.WriteString(highlight(options.Color, ansiBold, displayName(g.name)) + "\n")
+
+		for _, finding := range g.findings {
+			// the file is named once, above its findings
+			grouped := *finding
+			grouped.File = ""
+
+			out.WriteString(grouped.Render(options))
+		}
+
+		out.WriteString("\n")
+	}
+
+	if len(groups) > 0 {
+		writeSummary(&out, groups, options.Color)
+	}
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// writes the number of findings of every level per file and in total
+func writeSummary(out *strings.Builder, groups []*group, color bool) {
+	var (
+		levels = parse.Levels()
+		total  = &group{name: "total"}
+		width  = len("file")
+	)
+
+	for _, g := range groups {
+		total.findings = append(total.findings, g.findings...)
+		width = max(width, len(displayName(g.name)))
+	}
+
+	row := func(name string, cells func(level int) string) {
+		out.WriteString(fmt.Sprintf("%-*s", width, name))
+
+		for _, level := range levels {
+			// counts are aligned under the level names
+			out.WriteString("  " + cells(level))
+		}
+
+		out.WriteString("\n")
+	}
+
+	row("file", func(level int) string {
+		return parse.LevelName(level)
+	})
+
+	for _, g := range append(groups, total) {
+		row(displayName(g.name), func(level int) string {
+			count := g.count(level)
+			cell := fmt.Sprintf("%*d", len(parse.LevelName(level)), count)
+
+			if color && count > 0 {
+				return parse.ColorLevel(level, cell)
+			}
+
+			return cell
+		})
+	}
+}
+
+// Writes every finding on a single line, as
+// "file:line:column: level[code]: message" with
+// the line and column counted from 1
+func Compact(w io.Writer, findings []*parse.LinterError) error {
+	var out strings.Builder
+
+	for _, finding := range findings {
+		line, column := finding.Position()
+
+		label := parse.LevelName(finding.ParseLevel)
+		if code := finding.Code(); code != "" {
+			label += "[" + code + "]"
+		}
+
+		fmt.Fprintf(&out, "%s:%d:%d: %s: %s\n", displayName(finding.File), line, column+1, label, finding.Message)
+	}
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// commands of the GitHub Actions annotations, keyed by level
+var githubCommands = map[int]string{
+	parse.ParseLevelError:   "error",
+	parse.ParseLevelWarning: "warning",
+	parse.ParseLevelInfo:    "notice",
+	parse.ParseLevelHint:    "notice",
+}
+
+// escapes the data of a workflow command
+var githubData = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+// escapes the property values of a workflow command
+var githubProperty = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+
+// Writes every finding as a GitHub Actions workflow command,
+// "::error file=...,line=...,col=...,endLine=...,endColumn=...::message",
+// annotating the lines of the finding in the pull request. Infos and
+// hints are notices, and lines and columns are counted from 1.
+func GitHub(w io.Writer, findings []*parse.LinterError) error {
+	var out strings.Builder
+
+	for _, finding := range findings {
+		command, ok := githubCommands[finding.ParseLevel]
+		if !ok {
+			command = "error"
+		}
+
+		line, column := finding.Position()
+		endLine, endColumn := finding.EndPosition()
+
+		var properties []string
+
+		// findings of an unknown file are not attached to a file
+		if finding.File != "" {
+			properties = append(properties, "file="+githubProperty.Replace(filepath.ToSlash(filepath.Clean(finding.File))))
+		}
+
+		properties = append(properties,
+			fmt.Sprintf("line=%d", line),
+			fmt.Sprintf("col=%d", column+1),
+			fmt.Sprintf("endLine=%d", endLine),
+			fmt.Sprintf("endColumn=%d", endColumn+1),
+		)
+
+		if finding.Kind != nil {
+			properties = append(properties, "title="+githubProperty.Replace(finding.Kind.Error()))
+		}
+
+		fmt.Fprintf(&out, "::%s %s::%s\n", command, strings.Join(properties, ","), githubData.Replace(finding.Message))
+	}
+
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// returns the name of the file, or a placeholder
+// for findings of an unknown file
+func displayName(name string) string {
+	if name == "" {
+		return "<unknown file>"
+	}
+
+	return name
+}
+
+// returns the text wrapped in the ANSI sequence, when highlighted
+func highlight(color bool, sequence, text string) string {
+	if !color {
+		return text
+	}
+
+	return sequence + text + ansiReset
+}
@@ -0,0 +1,170 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bak-minsu/seclang-linter/pkg/parse"
+	"github.com/go-test/deep"
+)
+
+// constructs the expected output, one line per argument
+func joinLines(lines ...string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// returns findings of two files, in the order analyze.Analyze reports them
+func testFindings() []*parse.LinterError {
+	const (
+		rules   = "SecMarker END\nSecRule ARGS \"@rx a\" \"id:1,t:bogus\""
+		exclude = "SecRuleRemoveById 1"
+	)
+
+	return []*parse.LinterError{
+		{
+			File:       "rules.conf",
+			Kind:       &parse.Kind{Code: "SL2401", Name: "unused marker"},
+			Message:    `no skipAfter action jumps to marker "END"`,
+			ParseLevel: parse.ParseLevelInfo,
+			Offset:     0,
+			Distance:   13,
+			Contents:   rules,
+		},
+		{
+			File:       "rules.conf",
+			Kind:       &parse.Kind{Code: "SL2101", Name: "unknown transformation"},
+			Message:    `unknown transformation "bogus"`,
+			ParseLevel: parse.ParseLevelError,
+			Offset:     41,
+			Distance:   7,
+			Contents:   rules,
+		},
+		{
+			File:       "exclude.conf",
+			Message:    "exclusion before the rule",
+			ParseLevel: parse.ParseLevelWarning,
+			Offset:     18,
+			Distance:   1,
+			Contents:   exclude,
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		findings []*parse.LinterError
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "POSITIVE - pretty findings grouped by file with a summary",
+			format:   FormatPretty,
+			findings: testFindings(),
+			want: joinLines(
+				"rules.conf",
+				"",
+				`Info[SL2401]: no skipAfter action jumps to marker "END"`,
+				"line 1, column 0:",
+				"SecMarker END",
+				"^^^^^^^^^ ^^^",
+				"",
+				`Error[SL2101]: unknown transformation "bogus"`,
+				"line 2, column 27:",
+				`SecRule ARGS "@rx a" "id:1,t:bogus"`,
+				"                           ^^^^^^^",
+				"",
+				"exclude.conf",
+				"",
+				"Warning: exclusion before the rule",
+				"line 1, column 18:",
+				"SecRuleRemoveById 1",
+				"                  ^",
+				"",
+				"file          error  warning  info  hint",
+				"rules.conf        1        0     1     0",
+				"exclude.conf      0        1     0     0",
+				"total             1        1     1     0",
+			),
+		},
+		{
+			name:   "POSITIVE - pretty without findings",
+			format: FormatPretty,
+			want:   "",
+		},
+		{
+			name:     "POSITIVE - compact findings in reported order",
+			format:   FormatCompact,
+			findings: testFindings(),
+			want: joinLines(
+				`rules.conf:1:1: info[SL2401]: no skipAfter action jumps to marker "END"`,
+				`rules.conf:2:28: error[SL2101]: unknown transformation "bogus"`,
+				"exclude.conf:1:19: warning: exclusion before the rule",
+			),
+		},
+		{
+			name:     "POSITIVE - github annotations in reported order",
+			format:   FormatGitHub,
+			findings: testFindings(),
+			want: joinLines(
+				`::notice file=rules.conf,line=1,col=1,endLine=1,endColumn=13,title=SL2401 unused marker::no skipAfter action jumps to marker "END"`,
+				`::error file=rules.conf,line=2,col=28,endLine=2,endColumn=34,title=SL2101 unknown transformation::unknown transformation "bogus"`,
+				"::warning file=exclude.conf,line=1,col=19,endLine=1,endColumn=19::exclusion before the rule",
+			),
+		},
+		{
+			name:   "POSITIVE - github annotation escaping its properties and message",
+			format: FormatGitHub,
+			findings: []*parse.LinterError{
+				{
+					File:       "./rules/a,b:c.conf",
+					Message:    "100% wrong\nacross lines",
+					ParseLevel: parse.ParseLevelHint,
+					Offset:     8,
+					Distance:   10,
+					Contents:   "SecRule \"a\nb\" \"id:1\"",
+				},
+			},
+			want: joinLines(
+				"::notice file=rules/a%2Cb%3Ac.conf,line=1,col=9,endLine=2,endColumn=7::100%25 wrong%0Aacross lines",
+			),
+		},
+		{
+			name:   "POSITIVE - github annotation without a file",
+			format: FormatGitHub,
+			findings: []*parse.LinterError{
+				{
+					Message:    "expecting directive options",
+					ParseLevel: parse.ParseLevelError,
+					Offset:     0,
+					Distance:   9,
+					Contents:   "SecMarker",
+				},
+			},
+			want: joinLines(
+				"::error line=1,col=1,endLine=1,endColumn=9::expecting directive options",
+			),
+		},
+		{
+			name:     "NEGATIVE - unknown format",
+			format:   "xml",
+			findings: testFindings(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+
+			err := Write(&out, tt.format, tt.findings, parse.RenderOptions{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := deep.Equal(out.String(), tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
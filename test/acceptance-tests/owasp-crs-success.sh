@@ -1,41 +1,15 @@
 #!/usr/bin/env bash
 
-output=$(go run ./cmd/seclang-linter/seclang-linter.go run ./test/testdata/owasp-crs/*)
+output=$(go run ./cmd/seclang-linter/seclang-linter.go run --format pretty --data-root ./test/testdata/owasp-crs-data ./test/testdata/owasp-crs/* 2>&1)
 
-expected="
-validating file ./test/testdata/owasp-crs/REQUEST-901-INITIALIZATION.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-905-COMMON-EXCEPTIONS.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-911-METHOD-ENFORCEMENT.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-913-SCANNER-DETECTION.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-920-PROTOCOL-ENFORCEMENT.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-921-PROTOCOL-ATTACK.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-922-MULTIPART-ATTACK.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-930-APPLICATION-ATTACK-LFI.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-931-APPLICATION-ATTACK-RFI.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-932-APPLICATION-ATTACK-RCE.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-933-APPLICATION-ATTACK-PHP.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-934-APPLICATION-ATTACK-GENERIC.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-941-APPLICATION-ATTACK-XSS.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-942-APPLICATION-ATTACK-SQLI.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-943-APPLICATION-ATTACK-SESSION-FIXATION.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-944-APPLICATION-ATTACK-JAVA.conf.........success!
-validating file ./test/testdata/owasp-crs/REQUEST-949-BLOCKING-EVALUATION.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-950-DATA-LEAKAGES.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-951-DATA-LEAKAGES-SQL.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-952-DATA-LEAKAGES-JAVA.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-953-DATA-LEAKAGES-PHP.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-954-DATA-LEAKAGES-IIS.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-955-WEB-SHELLS.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-959-BLOCKING-EVALUATION.conf.........success!
-validating file ./test/testdata/owasp-crs/RESPONSE-980-CORRELATION.conf.........success!
-"
+expected=$(cat ./test/acceptance-tests/owasp-crs-success.txt)
 
-result=$(diff <(echo ${output}) <(echo ${expected}))
+result=$(diff <(echo "${output}") <(echo "${expected}"))
 
-if [ ! -z ${result} ]; then
+if [ -n "${result}" ]; then
     echo "test failed: ${result}"
 
     exit 1
 fi
 
-exit 0
\ No newline at end of file
+exit 0